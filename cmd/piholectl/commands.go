@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// command is a piholectl command, named by a noun and a verb
+type command struct {
+	name string
+
+	// args names the positional arguments, all of which are required
+	args []string
+
+	// keepSession leaves the session the command logged in with open
+	keepSession bool
+
+	// needsSession requires a session passed in through PIHOLE_SESSION_ID
+	needsSession bool
+
+	run func(ctx context.Context, client pihole.Client, args []string) (*output, error)
+}
+
+// usage describes the arguments of the command
+func (c *command) usage() string {
+	names := make([]string, 0, len(c.args))
+	for _, arg := range c.args {
+		names = append(names, "<"+arg+">")
+	}
+	return strings.Join(names, " ")
+}
+
+// commands are all piholectl commands
+var commands = []command{
+	{name: "dns list", run: dnsList},
+	{name: "dns add", args: []string{"domain", "ip"}, run: dnsAdd},
+	{name: "dns rm", args: []string{"domain"}, run: dnsRemove},
+	{name: "cname list", run: cnameList},
+	{name: "cname add", args: []string{"domain", "target"}, run: cnameAdd},
+	{name: "cname rm", args: []string{"domain"}, run: cnameRemove},
+	{name: "clients list", run: clientsList},
+	{name: "sessions list", run: sessionsList},
+	{name: "auth login", keepSession: true, run: authLogin},
+	{name: "auth logout", needsSession: true, run: authLogout},
+}
+
+// dnsRecord is a DNS record in JSON output
+type dnsRecord struct {
+	Domain string `json:"domain"`
+	IP     string `json:"ip"`
+}
+
+// dnsOutput formats DNS records
+func dnsOutput(records []pihole.DNSRecord) *output {
+	out := &output{header: []string{"DOMAIN", "IP"}}
+	values := make([]dnsRecord, 0, len(records))
+	for _, r := range records {
+		values = append(values, dnsRecord{Domain: r.Domain, IP: r.IP})
+		out.rows = append(out.rows, []string{r.Domain, r.IP})
+	}
+	out.value = values
+	return out
+}
+
+func dnsList(ctx context.Context, client pihole.Client, _ []string) (*output, error) {
+	records, err := client.LocalDNS().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dnsOutput(records), nil
+}
+
+func dnsAdd(ctx context.Context, client pihole.Client, args []string) (*output, error) {
+	if !pihole.ValidDomain(args[0]) {
+		return nil, errors.New(args[0] + " is not a valid domain")
+	}
+
+	record, err := client.LocalDNS().Create(ctx, args[0], args[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	return dnsOutput([]pihole.DNSRecord{*record}), nil
+}
+
+func dnsRemove(ctx context.Context, client pihole.Client, args []string) (*output, error) {
+	return nil, client.LocalDNS().Delete(ctx, args[0])
+}
+
+// cnameRecord is a CNAME record in JSON output
+type cnameRecord struct {
+	Domain string `json:"domain"`
+	Target string `json:"target"`
+}
+
+// cnameOutput formats CNAME records
+func cnameOutput(records []pihole.CNAMERecord) *output {
+	out := &output{header: []string{"DOMAIN", "TARGET"}}
+	values := make([]cnameRecord, 0, len(records))
+	for _, r := range records {
+		values = append(values, cnameRecord{Domain: r.Domain, Target: r.Target})
+		out.rows = append(out.rows, []string{r.Domain, r.Target})
+	}
+	out.value = values
+	return out
+}
+
+func cnameList(ctx context.Context, client pihole.Client, _ []string) (*output, error) {
+	records, err := client.LocalCNAME().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cnameOutput(records), nil
+}
+
+func cnameAdd(ctx context.Context, client pihole.Client, args []string) (*output, error) {
+	for _, domain := range args {
+		if !pihole.ValidDomain(domain) {
+			return nil, errors.New(domain + " is not a valid domain")
+		}
+	}
+
+	record, err := client.LocalCNAME().Create(ctx, args[0], args[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	return cnameOutput([]pihole.CNAMERecord{*record}), nil
+}
+
+func cnameRemove(ctx context.Context, client pihole.Client, args []string) (*output, error) {
+	return nil, client.LocalCNAME().Delete(ctx, args[0])
+}
+
+// clientRecord is a client in JSON output
+type clientRecord struct {
+	Client  string `json:"client"`
+	Name    string `json:"name"`
+	Comment string `json:"comment"`
+	Groups  []int  `json:"groups"`
+}
+
+func clientsList(ctx context.Context, client pihole.Client, _ []string) (*output, error) {
+	records, err := client.ClientManagement().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &output{header: []string{"CLIENT", "NAME", "COMMENT", "GROUPS"}}
+	values := make([]clientRecord, 0, len(records))
+	for _, r := range records {
+		groups := r.Groups
+		if groups == nil {
+			groups = []int{}
+		}
+		values = append(values, clientRecord{Client: r.Client, Name: r.Name, Comment: r.Comment, Groups: groups})
+
+		ids := make([]string, 0, len(groups))
+		for _, g := range groups {
+			ids = append(ids, strconv.Itoa(g))
+		}
+		out.rows = append(out.rows, []string{r.Client, r.Name, r.Comment, strings.Join(ids, ",")})
+	}
+	out.value = values
+	return out, nil
+}
+
+// session is an API session in JSON output
+type session struct {
+	ID         int    `json:"id"`
+	Current    bool   `json:"current"`
+	UserAgent  string `json:"user_agent"`
+	RemoteAddr string `json:"remote_addr"`
+	LoginAt    string `json:"login_at"`
+	LastActive string `json:"last_active"`
+	ValidUntil string `json:"valid_until"`
+}
+
+func sessionsList(ctx context.Context, client pihole.Client, _ []string) (*output, error) {
+	sessions, err := client.Sessions().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &output{header: []string{"ID", "CURRENT", "USER AGENT", "REMOTE ADDRESS", "LAST ACTIVE", "VALID UNTIL"}}
+	values := make([]session, 0, len(sessions))
+	for _, s := range sessions {
+		v := session{
+			ID:         s.ID,
+			Current:    s.Current,
+			UserAgent:  s.UserAgent,
+			RemoteAddr: s.RemoteAddr,
+			LoginAt:    timestamp(s.LoginAt),
+			LastActive: timestamp(s.LastActive),
+			ValidUntil: timestamp(s.ValidUntil),
+		}
+		values = append(values, v)
+		out.rows = append(out.rows, []string{
+			strconv.Itoa(v.ID), strconv.FormatBool(v.Current), v.UserAgent, v.RemoteAddr, v.LastActive, v.ValidUntil,
+		})
+	}
+	out.value = values
+	return out, nil
+}
+
+// timestamp formats a Unix timestamp as RFC 3339 in UTC
+func timestamp(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+// authLogin prints the ID of the session the client logged in with, which
+// later commands reuse through PIHOLE_SESSION_ID
+func authLogin(_ context.Context, client pihole.Client, _ []string) (*output, error) {
+	sid := client.SessionID()
+	return &output{
+		rows:  [][]string{{sid}},
+		value: map[string]string{"session_id": sid},
+	}, nil
+}
+
+// authLogout ends the session passed in through PIHOLE_SESSION_ID
+func authLogout(ctx context.Context, client pihole.Client, _ []string) (*output, error) {
+	return nil, client.Logout(ctx)
+}
@@ -0,0 +1,133 @@
+// Command piholectl runs ad-hoc operations against a Pi-hole through the
+// same client and configuration as the Terraform provider.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/provider"
+	"github.com/poindexter12/terraform-provider-pihole/internal/version"
+)
+
+// usage introduces the commands and global flags
+const usage = `Usage: piholectl [flags] <command> [arguments]
+
+Commands:
+  dns list                     list local DNS records
+  dns add <domain> <ip>        add a local DNS record
+  dns rm <domain>              remove the local DNS record of a domain
+  cname list                   list CNAME records
+  cname add <domain> <target>  add a CNAME record
+  cname rm <domain>            remove the CNAME record of a domain
+  clients list                 list clients
+  sessions list                list API sessions
+  auth login                   start a session and print its ID
+  auth logout                  end the session in PIHOLE_SESSION_ID
+
+The Pi-hole is configured like the provider when its block sets nothing,
+through PIHOLE_URL, PIHOLE_PASSWORD, PIHOLE_CA_FILE and the other PIHOLE_*
+environment variables. Set PIHOLE_SESSION_ID to the output of "auth login"
+to reuse a session instead of logging in for every command.
+
+Flags:
+`
+
+// sessionIDEnv names the environment variable holding a session to reuse
+const sessionIDEnv = "PIHOLE_SESSION_ID"
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs piholectl with args, the arguments following the program name,
+// and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("piholectl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	cfg := provider.ConfigFromEnv()
+	flags.StringVar(&cfg.URL, "url", cfg.URL, "Pi-hole URL, overriding PIHOLE_URL")
+	flags.StringVar(&cfg.CAFile, "ca-file", cfg.CAFile, "CA certificate file, overriding PIHOLE_CA_FILE")
+	flags.BoolVar(&cfg.InsecureSkipVerify, "insecure-skip-verify", cfg.InsecureSkipVerify, "skip TLS certificate verification")
+	format := formatTable
+	flags.Var(&format, "o", "output format, `table` or json")
+	flags.Var(&format, "output", "output format, `table` or json")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	cmd, err := findCommand(flags.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "%s\n\n", err)
+		flags.Usage()
+		return 2
+	}
+	cmdArgs := flags.Args()[2:]
+	if len(cmdArgs) != len(cmd.args) {
+		fmt.Fprintf(stderr, "Usage: piholectl %s %s\n", cmd.name, cmd.usage())
+		return 2
+	}
+
+	cfg.UserAgent = fmt.Sprintf("piholectl/%s", version.ProviderVersion)
+	cfg.SessionID = os.Getenv(sessionIDEnv)
+
+	// Without a session the client would log in, only for the command to end
+	// that new session
+	if cmd.needsSession && cfg.SessionID == "" {
+		fmt.Fprintf(stderr, "Error: %s is not set\n", sessionIDEnv)
+		return 1
+	}
+
+	client, err := cfg.Client(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to instantiate client: %s\n", err)
+		return 1
+	}
+
+	// Sessions passed in are reused by later commands, and "auth login" hands
+	// its session to the caller, so only sessions of a single command end here
+	if cfg.SessionID == "" && !cmd.keepSession {
+		defer client.Logout(context.Background()) //nolint:errcheck // Best effort, the session expires anyway
+	}
+
+	out, err := cmd.run(ctx, client, cmdArgs)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	if out != nil {
+		if err := out.write(stdout, format); err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// findCommand returns the command named by the first two arguments
+func findCommand(args []string) (*command, error) {
+	if len(args) < 2 {
+		return nil, errors.New("missing command")
+	}
+
+	name := args[0] + " " + args[1]
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown command %q", name)
+}
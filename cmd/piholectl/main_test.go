@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+// testRun runs piholectl against srv and returns its output, failing the
+// test unless it exits with code
+func testRun(t *testing.T, srv *fake.Server, code int, args ...string) (string, string) {
+	t.Helper()
+
+	t.Setenv("PIHOLE_URL", srv.URL)
+	t.Setenv("PIHOLE_PASSWORD", fake.DefaultPassword)
+
+	var stdout, stderr bytes.Buffer
+	if got := run(context.Background(), args, &stdout, &stderr); got != code {
+		t.Fatalf("piholectl %s: expected exit code %d, got %d: %s", strings.Join(args, " "), code, got, stderr.String())
+	}
+	return stdout.String(), stderr.String()
+}
+
+func TestDNS(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.SetHosts("10.0.0.1 router.lan")
+
+	testRun(t, srv, 0, "dns", "add", "nas.lan", "10.0.0.2")
+
+	out, _ := testRun(t, srv, 0, "dns", "list")
+	expected := `DOMAIN      IP
+router.lan  10.0.0.1
+nas.lan     10.0.0.2
+`
+	if out != expected {
+		t.Errorf("expected table:\n%s\ngot:\n%s", expected, out)
+	}
+
+	testRun(t, srv, 0, "dns", "rm", "router.lan")
+
+	out, _ = testRun(t, srv, 0, "-o", "json", "dns", "list")
+	var records []dnsRecord
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0] != (dnsRecord{Domain: "nas.lan", IP: "10.0.0.2"}) {
+		t.Errorf("unexpected records: %v", records)
+	}
+
+	if n := len(srv.Sessions()); n != 0 {
+		t.Errorf("expected every command to log out, got %d sessions", n)
+	}
+}
+
+func TestCNAME(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	out, _ := testRun(t, srv, 0, "-output", "json", "cname", "add", "www.lan", "nas.lan")
+	if !strings.Contains(out, `"target": "nas.lan"`) {
+		t.Errorf("expected the created record, got %s", out)
+	}
+
+	out, _ = testRun(t, srv, 0, "cname", "list")
+	if out != "DOMAIN   TARGET\nwww.lan  nas.lan\n" {
+		t.Errorf("unexpected table:\n%s", out)
+	}
+
+	testRun(t, srv, 0, "cname", "rm", "www.lan")
+	if cnames := srv.CNAMEs(); len(cnames) != 0 {
+		t.Errorf("expected no CNAME records, got %v", cnames)
+	}
+
+	_, stderr := testRun(t, srv, 1, "cname", "add", "www.lan", "not a domain")
+	if !strings.Contains(stderr, "not a valid domain") {
+		t.Errorf("expected a validation error, got %s", stderr)
+	}
+}
+
+func TestClients(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.AddClient("192.168.1.10", "Laptop")
+
+	out, _ := testRun(t, srv, 0, "-o", "json", "clients", "list")
+	var clients []clientRecord
+	if err := json.Unmarshal([]byte(out), &clients); err != nil {
+		t.Fatal(err)
+	}
+	if len(clients) != 1 || clients[0].Client != "192.168.1.10" || clients[0].Comment != "Laptop" {
+		t.Errorf("unexpected clients: %v", clients)
+	}
+}
+
+func TestAuthSessions(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	sid, _ := testRun(t, srv, 0, "auth", "login")
+	sid = strings.TrimSpace(sid)
+	if sid == "" {
+		t.Fatal("expected a session ID")
+	}
+	t.Setenv(sessionIDEnv, sid)
+
+	out, _ := testRun(t, srv, 0, "-o", "json", "sessions", "list")
+	var sessions []session
+	if err := json.Unmarshal([]byte(out), &sessions); err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || !sessions[0].Current || !strings.HasPrefix(sessions[0].UserAgent, "piholectl/") {
+		t.Errorf("expected only the reused session, got %v", sessions)
+	}
+
+	testRun(t, srv, 0, "auth", "logout")
+	if n := len(srv.Sessions()); n != 0 {
+		t.Errorf("expected the session to end, got %d sessions", n)
+	}
+}
+
+func TestAuthLogoutWithoutSession(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	// Logging in would fail with another error
+	srv.SetMaxSessions(0)
+
+	t.Setenv(sessionIDEnv, "")
+	_, stderr := testRun(t, srv, 1, "auth", "logout")
+	if !strings.Contains(stderr, sessionIDEnv+" is not set") {
+		t.Errorf("expected an error about the missing session, got %s", stderr)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	_, stderr := testRun(t, srv, 2, "dns", "remove")
+	if !strings.Contains(stderr, `unknown command "dns remove"`) {
+		t.Errorf("expected an unknown command error, got %s", stderr)
+	}
+
+	_, stderr = testRun(t, srv, 2, "dns", "add", "nas.lan")
+	if !strings.Contains(stderr, "Usage: piholectl dns add <domain> <ip>") {
+		t.Errorf("expected the command usage, got %s", stderr)
+	}
+
+	testRun(t, srv, 2, "-o", "yaml", "dns", "list")
+}
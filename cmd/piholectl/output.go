@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats
+const (
+	formatTable = outputFormat("table")
+	formatJSON  = outputFormat("json")
+)
+
+// outputFormat is the value of the -o flag
+type outputFormat string
+
+// String implements flag.Value
+func (f *outputFormat) String() string {
+	return string(*f)
+}
+
+// Set implements flag.Value
+func (f *outputFormat) Set(value string) error {
+	switch outputFormat(value) {
+	case formatTable, formatJSON:
+		*f = outputFormat(value)
+		return nil
+	default:
+		return fmt.Errorf("expected %s or %s", formatTable, formatJSON)
+	}
+}
+
+// output is the result of a command, as table rows and as a value for JSON
+type output struct {
+	// header names the table columns. Tables without a header print only
+	// their rows, so that the output can be used in scripts.
+	header []string
+	rows   [][]string
+
+	value interface{}
+}
+
+// write writes the output to w in format
+func (o *output) write(w io.Writer, format outputFormat) error {
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(o.value)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if o.header != nil {
+		fmt.Fprintln(tw, strings.Join(o.header, "\t"))
+	}
+	for _, row := range o.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,8 @@
+data "pihole_client" "laptop" {
+  client        = "AA:BB:CC:DD:EE:FF"
+  allow_missing = true
+}
+
+output "laptop_configured" {
+  value = data.pihole_client.laptop.found
+}
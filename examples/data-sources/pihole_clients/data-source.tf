@@ -1 +1,7 @@
 data "pihole_clients" "all" {}
+
+# Clients identified by MAC address in group 2
+data "pihole_clients" "devices" {
+  type   = "mac"
+  groups = [2]
+}
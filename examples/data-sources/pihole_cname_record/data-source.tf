@@ -0,0 +1,7 @@
+data "pihole_cname_record" "www" {
+  domain = "www.lan"
+}
+
+output "www_target" {
+  value = data.pihole_cname_record.www.target
+}
@@ -7,3 +7,8 @@ data "pihole_cname_records" "records" {}
 data "pihole_cname_records" "records" {
     depends_on = [RESOURCE_IDENTIFIER]
 }
+
+# Aliases of the web server
+data "pihole_cname_records" "web" {
+  target = "web.home.arpa"
+}
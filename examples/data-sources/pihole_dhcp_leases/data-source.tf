@@ -0,0 +1,10 @@
+data "pihole_dhcp_leases" "all" {}
+
+# Look up the lease of a single device
+data "pihole_dhcp_leases" "laptop" {
+  hwaddr = "AA:BB:CC:DD:EE:FF"
+}
+
+output "laptop_ip" {
+  value = one(data.pihole_dhcp_leases.laptop.leases[*].ip)
+}
@@ -0,0 +1,13 @@
+data "pihole_dns_record" "nas" {
+  domain = "nas.lan"
+}
+
+# Look up a record that may not exist yet
+data "pihole_dns_record" "backup" {
+  domain        = "backup.lan"
+  allow_missing = true
+}
+
+output "backup_ip" {
+  value = data.pihole_dns_record.backup.found ? data.pihole_dns_record.backup.ip : "not configured"
+}
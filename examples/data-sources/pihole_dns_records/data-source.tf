@@ -1 +1,11 @@
 data "pihole_dns_records" "records" {}
+
+# Records in the home.arpa zone on the server subnet
+data "pihole_dns_records" "servers" {
+  domain_suffix = "home.arpa"
+  ip_cidr       = "192.168.10.0/24"
+}
+
+output "server_ips" {
+  value = data.pihole_dns_records.servers.ips_by_domain
+}
@@ -0,0 +1,17 @@
+variable "saas_domains" {
+  type    = set(string)
+  default = ["app.example.com", "api.example.com"]
+}
+
+data "pihole_domain_search" "saas" {
+  for_each = var.saas_domains
+  domain   = each.key
+}
+
+# Fail the plan if any SaaS domain is blocked
+check "saas_domains_not_blocked" {
+  assert {
+    condition     = alltrue([for s in data.pihole_domain_search.saas : !s.blocked])
+    error_message = "Blocked SaaS domains: ${join(", ", [for s in data.pihole_domain_search.saas : s.domain if s.blocked])}"
+  }
+}
@@ -0,0 +1,5 @@
+data "pihole_info_database" "this" {}
+
+output "database_size" {
+  value = data.pihole_info_database.this.size
+}
@@ -0,0 +1,9 @@
+data "pihole_info_ftl" "this" {}
+
+output "ftl" {
+  value = {
+    version       = data.pihole_info_ftl.this.version
+    pid           = data.pihole_info_ftl.this.pid
+    privacy_level = data.pihole_info_ftl.this.privacy_level
+  }
+}
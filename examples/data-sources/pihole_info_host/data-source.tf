@@ -0,0 +1,5 @@
+data "pihole_info_host" "this" {}
+
+output "model" {
+  value = data.pihole_info_host.this.model
+}
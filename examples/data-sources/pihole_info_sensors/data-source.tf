@@ -0,0 +1,8 @@
+data "pihole_info_sensors" "this" {}
+
+check "cpu_temperature" {
+  assert {
+    condition     = data.pihole_info_sensors.this.cpu_temp < data.pihole_info_sensors.this.hot_limit
+    error_message = "The Pi-hole CPU is running hot."
+  }
+}
@@ -0,0 +1,5 @@
+data "pihole_info_system" "this" {}
+
+output "memory_percent_used" {
+  value = data.pihole_info_system.this.memory_percent_used
+}
@@ -0,0 +1,13 @@
+# Devices active on the LAN during the last week
+data "pihole_network_devices" "lan" {
+  interface   = "eth0"
+  seen_within = "168h"
+}
+
+# Manage every one of them as a Pi-hole client
+resource "pihole_client" "lan" {
+  for_each = { for d in data.pihole_network_devices.lan.devices : d.hwaddr => d }
+
+  client  = upper(each.key)
+  comment = each.value.vendor
+}
@@ -0,0 +1,11 @@
+# Queries from the TV blocked by the blocklists since the start of the year
+data "pihole_queries" "tv_blocked" {
+  client_ip   = "192.168.1.100"
+  status      = "GRAVITY"
+  from        = "2024-01-01T00:00:00Z"
+  max_results = 500
+}
+
+output "tv_blocked_domains" {
+  value = distinct(data.pihole_queries.tv_blocked.queries[*].domain)
+}
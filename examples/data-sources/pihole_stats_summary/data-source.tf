@@ -0,0 +1,5 @@
+data "pihole_stats_summary" "this" {}
+
+output "percent_blocked" {
+  value = data.pihole_stats_summary.this.percent_blocked
+}
@@ -0,0 +1,3 @@
+data "pihole_stats_top_clients" "this" {
+  limit = 5
+}
@@ -0,0 +1,5 @@
+# The five most blocked domains
+data "pihole_stats_top_domains" "blocked" {
+  blocked = true
+  limit   = 5
+}
@@ -0,0 +1 @@
+data "pihole_stats_upstreams" "this" {}
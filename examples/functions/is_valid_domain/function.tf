@@ -0,0 +1,8 @@
+variable "domain" {
+  type = string
+
+  validation {
+    condition     = provider::pihole::is_valid_domain(var.domain)
+    error_message = "The domain is not a valid Pi-hole domain."
+  }
+}
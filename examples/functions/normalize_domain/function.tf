@@ -0,0 +1,4 @@
+# Returns "nas.lan"
+output "domain" {
+  value = provider::pihole::normalize_domain("NAS.lan.")
+}
@@ -0,0 +1,11 @@
+# Manage the records of a Pi-hole v5 05-pihole-custom-cname.conf export
+locals {
+  cnames = provider::pihole::parse_cname_list(file("${path.module}/05-pihole-custom-cname.conf"))
+}
+
+resource "pihole_cname_record" "imported" {
+  for_each = { for record in local.cnames : record.domain => record.target }
+
+  domain = each.key
+  target = each.value
+}
@@ -0,0 +1,11 @@
+# Manage the records of a Pi-hole v5 custom.list export
+locals {
+  hosts = provider::pihole::parse_hosts(file("${path.module}/custom.list"))
+}
+
+resource "pihole_dns_record" "imported" {
+  for_each = { for record in local.hosts : record.domain => record.ip }
+
+  domain = each.key
+  ip     = each.value
+}
@@ -15,3 +15,14 @@ resource "pihole_client" "server" {
   client  = "homeserver.local"
   comment = "Home Server"
 }
+
+# Manage a Pi-hole client by network interface
+resource "pihole_client" "guest_wifi" {
+  client  = ":wlan0"
+  comment = "Guest network"
+}
+
+# Pi-hole's database ID of the client, which groups refer to clients by
+output "laptop_database_id" {
+  value = pihole_client.laptop.database_id
+}
@@ -0,0 +1,4 @@
+# Revoke the lease of 192.168.1.50, forcing the client to request a new one
+resource "pihole_dhcp_lease_removal" "laptop" {
+  ip = "192.168.1.50"
+}
@@ -0,0 +1,6 @@
+# Bump the value to flush the ARP cache again
+resource "pihole_flush_arp" "this" {
+  triggers = {
+    run = "1"
+  }
+}
@@ -0,0 +1,6 @@
+# Empty the query log of the guest Pi-hole on the first apply of each day
+resource "pihole_flush_logs" "daily" {
+  triggers = {
+    day = formatdate("YYYY-MM-DD", plantimestamp())
+  }
+}
@@ -0,0 +1,6 @@
+# Bump the value to empty the network table again
+resource "pihole_flush_network" "this" {
+  triggers = {
+    run = "1"
+  }
+}
@@ -0,0 +1,9 @@
+# Remove devices not seen for 30 days from the network table
+resource "pihole_network_device_cleanup" "stale" {
+  not_seen_within = "720h"
+
+  # Run the cleanup again on every apply
+  triggers = {
+    always = timestamp()
+  }
+}
@@ -0,0 +1,6 @@
+# Restart the resolver whenever the hosts file managed outside of Terraform changes
+resource "pihole_restart_dns" "hosts" {
+  triggers = {
+    hosts = filesha256("${path.module}/hosts")
+  }
+}
@@ -0,0 +1,118 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/provider"
+	"github.com/poindexter12/terraform-provider-pihole/internal/version"
+)
+
+// usage introduces the flags of the generate command
+const usage = `Usage: terraform-provider-pihole generate [flags]
+
+Writes Terraform configuration with a resource and an import block for every
+local DNS record, CNAME record and client of a Pi-hole, so that running
+"terraform apply" adopts them. Existing files are never overwritten.
+
+The Pi-hole is configured like the provider when its block sets nothing,
+through the environment variables
+%s
+Flags:
+`
+
+// Run runs the generate command with args, the arguments following
+// "generate", and returns the exit code
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, usage, "  "+strings.Join(provider.EnvVars, "\n  ")+"\n")
+		flags.PrintDefaults()
+	}
+
+	out := flags.String("out", ".", "directory to write the configuration files to")
+	filter := flags.String("filter", "", "only include DNS and CNAME records for this domain and its subdomains, such as `home.lan`")
+	kinds := flags.String("resources", strings.Join(Kinds, ","), "comma-separated kinds of objects to include")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected argument %q\n", flags.Arg(0))
+		return 2
+	}
+
+	opts := Options{DomainSuffix: *filter}
+	for _, kind := range strings.Split(*kinds, ",") {
+		kind = strings.TrimSpace(kind)
+		if !slices.Contains(Kinds, kind) {
+			fmt.Fprintf(stderr, "unknown kind %q, expected one of %s\n", kind, strings.Join(Kinds, ", "))
+			return 2
+		}
+		opts.Kinds = append(opts.Kinds, kind)
+	}
+
+	if err := generate(ctx, *out, opts, stdout, stderr); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// generate connects to Pi-hole and writes the configuration files to dir
+func generate(ctx context.Context, dir string, opts Options, stdout, stderr io.Writer) error {
+	cfg := provider.ConfigFromEnv()
+	cfg.UserAgent = fmt.Sprintf("terraform-provider-pihole/%s generate", version.ProviderVersion)
+
+	client, err := cfg.Client(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to instantiate client: %w", err)
+	}
+	defer client.Logout(context.Background()) //nolint:errcheck // Best effort, the session expires anyway
+
+	files, err := Generate(ctx, client, opts)
+	if err != nil {
+		return err
+	}
+
+	// Check every file first so nothing is written if one already exists
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(dir, f.Name)); !errors.Is(err, fs.ErrNotExist) {
+			if err == nil {
+				err = fmt.Errorf("%s already exists", filepath.Join(dir, f.Name))
+			}
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.Name)
+		if err := os.WriteFile(path, f.Content, 0o644); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "Wrote %d resources to %s\n", f.Resources, path)
+		for _, reason := range f.Skipped {
+			fmt.Fprintf(stderr, "Skipped %s\n", reason)
+		}
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(stdout, "Nothing to generate")
+	}
+
+	return nil
+}
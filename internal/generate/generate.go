@@ -0,0 +1,300 @@
+// Package generate writes Terraform configuration adopting the records and
+// clients of an existing Pi-hole: a resource and an import block for each.
+package generate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Kinds of objects the generator can write
+const (
+	KindDNS    = "dns"
+	KindCNAME  = "cname"
+	KindClient = "client"
+)
+
+// Kinds are all kinds of objects, in the order their files are written
+var Kinds = []string{KindDNS, KindCNAME, KindClient}
+
+// Options selects the objects to generate configuration for
+type Options struct {
+	// Kinds are the kinds of objects to include. Empty includes all.
+	Kinds []string
+
+	// DomainSuffix only includes DNS and CNAME records for this domain and
+	// its subdomains. Empty includes all records.
+	DomainSuffix string
+}
+
+// File is a generated configuration file
+type File struct {
+	Name    string
+	Content []byte
+
+	// Resources is the number of resources in the file
+	Resources int
+
+	// Skipped describes objects that could not be included
+	Skipped []string
+}
+
+// Generate reads the objects selected by opts from client and returns a
+// configuration file for each kind with at least one object. Resource names
+// are derived from the domain or client identifier, so regenerating yields
+// the same names.
+func Generate(ctx context.Context, client pihole.Client, opts Options) ([]File, error) {
+	var files []File
+	for _, kind := range Kinds {
+		if len(opts.Kinds) > 0 && !slices.Contains(opts.Kinds, kind) {
+			continue
+		}
+
+		var (
+			file File
+			err  error
+		)
+		switch kind {
+		case KindDNS:
+			file, err = dnsRecords(ctx, client, opts.DomainSuffix)
+		case KindCNAME:
+			file, err = cnameRecords(ctx, client, opts.DomainSuffix)
+		case KindClient:
+			file, err = clients(ctx, client)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if file.Resources > 0 || len(file.Skipped) > 0 {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// dnsRecords generates pihole_dns_record resources
+func dnsRecords(ctx context.Context, client pihole.Client, suffix string) (File, error) {
+	records, err := client.LocalDNS().List(ctx)
+	if err != nil {
+		return File{}, fmt.Errorf("listing DNS records: %w", err)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Domain != records[j].Domain {
+			return records[i].Domain < records[j].Domain
+		}
+		return records[i].IP < records[j].IP
+	})
+
+	w := newWriter("dns_records.tf", "pihole_dns_record", "dns")
+	seen := map[string]bool{}
+	for _, r := range records {
+		if !pihole.HasDomainSuffix(r.Domain, suffix) {
+			continue
+		}
+
+		// pihole_dns_record is identified by its domain, so only one record
+		// of a domain can be imported
+		if seen[r.Domain] {
+			w.skip(fmt.Sprintf("DNS record %s %s: pihole_dns_record manages one record per domain", r.Domain, r.IP))
+			continue
+		}
+		seen[r.Domain] = true
+
+		w.resource(r.Domain, r.Domain, map[string]cty.Value{
+			"domain": cty.StringVal(r.Domain),
+			"ip":     cty.StringVal(r.IP),
+		})
+	}
+
+	return w.file(), nil
+}
+
+// cnameRecords generates pihole_cname_record resources
+func cnameRecords(ctx context.Context, client pihole.Client, suffix string) (File, error) {
+	records, err := client.LocalCNAME().List(ctx)
+	if err != nil {
+		return File{}, fmt.Errorf("listing CNAME records: %w", err)
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Domain < records[j].Domain })
+
+	w := newWriter("cname_records.tf", "pihole_cname_record", "cname")
+	for _, r := range records {
+		if !pihole.HasDomainSuffix(r.Domain, suffix) {
+			continue
+		}
+
+		w.resource(r.Domain, r.Domain, map[string]cty.Value{
+			"domain": cty.StringVal(r.Domain),
+			"target": cty.StringVal(r.Target),
+		})
+	}
+
+	return w.file(), nil
+}
+
+// clients generates pihole_client resources
+func clients(ctx context.Context, client pihole.Client) (File, error) {
+	records, err := client.ClientManagement().List(ctx)
+	if err != nil {
+		return File{}, fmt.Errorf("listing clients: %w", err)
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Client < records[j].Client })
+
+	w := newWriter("clients.tf", "pihole_client", "client")
+	for _, r := range records {
+		attrs := map[string]cty.Value{"client": cty.StringVal(r.Client)}
+		if r.Comment != "" {
+			attrs["comment"] = cty.StringVal(r.Comment)
+		}
+
+		// Most identifiers start with a digit or colon, so every name is
+		// prefixed alike
+		w.resource("client "+r.Client, r.Client, attrs)
+	}
+
+	return w.file(), nil
+}
+
+// writer builds the configuration file of one resource type
+type writer struct {
+	name         string
+	resourceType string
+	prefix       string
+
+	resources []object
+	skipped   []string
+}
+
+// object is a resource the writer names and writes once all are known
+type object struct {
+	key   string
+	id    string
+	attrs map[string]cty.Value
+}
+
+// newWriter returns a writer for the resources of resourceType. Names that
+// would not start with a letter are prefixed with prefix.
+func newWriter(name, resourceType, prefix string) *writer {
+	return &writer{
+		name:         name,
+		resourceType: resourceType,
+		prefix:       prefix,
+	}
+}
+
+// resource adds a resource named after key with attrs, and the import block
+// adopting the object with ID id
+func (w *writer) resource(key, id string, attrs map[string]cty.Value) {
+	w.resources = append(w.resources, object{key: key, id: id, attrs: attrs})
+}
+
+// write writes the resource and import block of o named name
+func (w *writer) write(f *hclwrite.File, o object, name string) {
+	body := f.Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+
+	block := body.AppendNewBlock("resource", []string{w.resourceType, name}).Body()
+	keys := make([]string, 0, len(o.attrs))
+	for k := range o.attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		block.SetAttributeValue(k, o.attrs[k])
+	}
+
+	body.AppendNewline()
+	imp := body.AppendNewBlock("import", nil).Body()
+	imp.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: w.resourceType},
+		hcl.TraverseAttr{Name: name},
+	})
+	imp.SetAttributeValue("id", cty.StringVal(o.id))
+}
+
+// skip records an object that could not be included
+func (w *writer) skip(reason string) {
+	w.skipped = append(w.skipped, reason)
+}
+
+// file returns the generated file
+func (w *writer) file() File {
+	f := hclwrite.NewEmptyFile()
+	for i, name := range w.resourceNames() {
+		w.write(f, w.resources[i], name)
+	}
+
+	return File{
+		Name:      w.name,
+		Content:   f.Bytes(),
+		Resources: len(w.resources),
+		Skipped:   w.skipped,
+	}
+}
+
+// invalidNameChars are the characters not allowed in resource names
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// resourceNames returns the names of the resources, derived from their keys.
+// Keys that map to the same name all get a suffix derived from the key
+// itself, so that adding or removing an object never renames the others.
+func (w *writer) resourceNames() []string {
+	bases := make([]string, len(w.resources))
+	counts := map[string]int{}
+	for i, o := range w.resources {
+		bases[i] = w.baseName(o.key)
+		counts[bases[i]]++
+	}
+
+	names := make([]string, len(w.resources))
+	taken := map[string]bool{}
+	for i, o := range w.resources {
+		name := bases[i]
+		if counts[name] > 1 {
+			name += "_" + keyHash(o.key)
+		}
+		// A suffixed name can only clash with another name by chance
+		unique := name
+		for n := 2; taken[unique]; n++ {
+			unique = fmt.Sprintf("%s_%d", name, n)
+		}
+		taken[unique] = true
+		names[i] = unique
+	}
+
+	return names
+}
+
+// baseName derives a resource name from key
+func (w *writer) baseName(key string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(key), "_"), "_-")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = w.prefix + "_" + name
+	}
+	return name
+}
+
+// keyHash returns a short hash of key that tells resources with the same
+// base name apart
+func keyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:3])
+}
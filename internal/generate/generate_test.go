@@ -0,0 +1,219 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+	"github.com/poindexter12/terraform-provider-pihole/internal/provider"
+)
+
+// seed populates srv with records and clients to generate configuration for
+func seed(srv *fake.Server) {
+	srv.SetHosts("10.0.0.2 nas.home.lan", "10.0.0.1 router.lan", "fd00::2 nas.home.lan", "10.0.0.3 nas-home.lan")
+	srv.SetCNAMEs("www.home.lan,nas.home.lan", "1password.lan,nas.home.lan")
+	srv.AddClient("192.168.1.10", "Laptop")
+	srv.AddClient(":eth1", "")
+}
+
+func TestRun(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	seed(srv)
+
+	t.Setenv("PIHOLE_URL", srv.URL)
+	t.Setenv("PIHOLE_PASSWORD", fake.DefaultPassword)
+
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	if code := Run(context.Background(), []string{"-out", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+
+	expected := map[string]string{
+		"dns_records.tf": `resource "pihole_dns_record" "nas-home_lan" {
+  domain = "nas-home.lan"
+  ip     = "10.0.0.3"
+}
+
+import {
+  to = pihole_dns_record.nas-home_lan
+  id = "nas-home.lan"
+}
+
+resource "pihole_dns_record" "nas_home_lan" {
+  domain = "nas.home.lan"
+  ip     = "10.0.0.2"
+}
+
+import {
+  to = pihole_dns_record.nas_home_lan
+  id = "nas.home.lan"
+}
+
+resource "pihole_dns_record" "router_lan" {
+  domain = "router.lan"
+  ip     = "10.0.0.1"
+}
+
+import {
+  to = pihole_dns_record.router_lan
+  id = "router.lan"
+}
+`,
+		"cname_records.tf": `resource "pihole_cname_record" "cname_1password_lan" {
+  domain = "1password.lan"
+  target = "nas.home.lan"
+}
+
+import {
+  to = pihole_cname_record.cname_1password_lan
+  id = "1password.lan"
+}
+
+resource "pihole_cname_record" "www_home_lan" {
+  domain = "www.home.lan"
+  target = "nas.home.lan"
+}
+
+import {
+  to = pihole_cname_record.www_home_lan
+  id = "www.home.lan"
+}
+`,
+		"clients.tf": `resource "pihole_client" "client_192_168_1_10" {
+  client  = "192.168.1.10"
+  comment = "Laptop"
+}
+
+import {
+  to = pihole_client.client_192_168_1_10
+  id = "192.168.1.10"
+}
+
+resource "pihole_client" "client_eth1" {
+  client = ":eth1"
+}
+
+import {
+  to = pihole_client.client_eth1
+  id = ":eth1"
+}
+`,
+	}
+	for name, content := range expected {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Errorf("%s:\n%s\nexpected:\n%s", name, b, content)
+		}
+	}
+
+	if !strings.Contains(stderr.String(), "Skipped DNS record nas.home.lan fd00::2") {
+		t.Errorf("duplicate domain not reported: %s", stderr.String())
+	}
+
+	// Files are never overwritten
+	stdout.Reset()
+	stderr.Reset()
+	if code := Run(context.Background(), []string{"-out", dir}, &stdout, &stderr); code != 1 {
+		t.Errorf("expected exit code 1 when files exist, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "already exists") {
+		t.Errorf("unexpected error: %s", stderr.String())
+	}
+}
+
+func TestRunFilter(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	seed(srv)
+
+	t.Setenv("PIHOLE_URL", srv.URL)
+	t.Setenv("PIHOLE_PASSWORD", fake.DefaultPassword)
+
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	if code := Run(context.Background(), []string{"--filter", "home.lan", "--resources", "dns,cname", "--out", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr.String())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if strings.Join(names, ",") != "cname_records.tf,dns_records.tf" {
+		t.Fatalf("unexpected files %v", names)
+	}
+
+	dns, err := os.ReadFile(filepath.Join(dir, "dns_records.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(dns), "router.lan") || strings.Contains(string(dns), "nas-home.lan") || !strings.Contains(string(dns), "nas.home.lan") {
+		t.Errorf("filter not applied:\n%s", dns)
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run(context.Background(), []string{"--resources", "groups"}, &stdout, &stderr); code != 2 {
+		t.Errorf("expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), `unknown kind "groups"`) {
+		t.Errorf("unexpected error: %s", stderr.String())
+	}
+}
+
+func TestRunUsageEnvVars(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run(context.Background(), []string{"-h"}, &stdout, &stderr); code != 0 {
+		t.Errorf("expected exit code 0, got %d", code)
+	}
+	for _, name := range provider.EnvVars {
+		if !strings.Contains(stderr.String(), name) {
+			t.Errorf("usage does not mention %s:\n%s", name, stderr.String())
+		}
+	}
+}
+
+func TestResourceNamesStable(t *testing.T) {
+	names := func(keys ...string) map[string]string {
+		w := newWriter("clients.tf", "pihole_client", "client")
+		for _, key := range keys {
+			w.resource("client "+key, key, nil)
+		}
+		named := map[string]string{}
+		for i, name := range w.resourceNames() {
+			named[keys[i]] = name
+		}
+		return named
+	}
+
+	// Both clients map to client_fd00_1, so both are told apart by a hash
+	before := names("fd00::1", "fd00:1::", "laptop")
+	if before["fd00::1"] == before["fd00:1::"] || !strings.HasPrefix(before["fd00::1"], "client_fd00_1_") {
+		t.Errorf("colliding names not told apart: %v", before)
+	}
+	if before["laptop"] != "client_laptop" {
+		t.Errorf("expected laptop to keep its name, got %q", before["laptop"])
+	}
+
+	// Another colliding client, sorted first, renames none of them
+	after := names("FD00::1", "fd00::1", "fd00:1::", "laptop")
+	for key, name := range before {
+		if after[key] != name {
+			t.Errorf("%s renamed from %s to %s", key, name, after[key])
+		}
+	}
+}
@@ -14,9 +14,37 @@ type Client interface {
 	// ClientManagement returns the service for managing Pi-hole clients
 	ClientManagement() ClientManagementService
 
+	// Network returns the service for Pi-hole's table of seen network devices
+	Network() NetworkService
+
+	// DHCP returns the service for Pi-hole's DHCP leases
+	DHCP() DHCPService
+
+	// Stats returns the service for Pi-hole's query statistics
+	Stats() StatsService
+
+	// Queries returns the service for Pi-hole's query log
+	Queries() QueryService
+
+	// Search returns the service for looking up why a domain is (not) blocked
+	Search() SearchService
+
+	// Info returns the service for system, host and FTL information
+	Info() InfoService
+
+	// Actions returns the service for restarting DNS and flushing tables
+	Actions() ActionService
+
+	// Sessions returns the service for listing and revoking API sessions
+	Sessions() SessionService
+
 	// SessionID returns the current session ID (for reuse across provider instances)
 	SessionID() string
 
+	// RevokedSessions returns the stale sessions revoked after logging in
+	// when Config.ReapStaleSessions is set
+	RevokedSessions() []Session
+
 	// Logout terminates the current session with Pi-hole.
 	// This should be called when the provider is done to free up session slots.
 	Logout(ctx context.Context) error
@@ -55,3 +83,68 @@ type ClientManagementService interface {
 	Update(ctx context.Context, client, comment string) (*ClientRecord, error)
 	Delete(ctx context.Context, client string) error
 }
+
+// NetworkService reads and prunes Pi-hole's network table
+type NetworkService interface {
+	List(ctx context.Context) ([]NetworkDevice, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// DHCPService reads and revokes the leases of Pi-hole's DHCP server
+type DHCPService interface {
+	List(ctx context.Context) ([]DHCPLease, error)
+	Delete(ctx context.Context, ip string) error
+}
+
+// StatsService reads Pi-hole's query statistics.
+// The values change continuously as Pi-hole answers queries.
+type StatsService interface {
+	Summary(ctx context.Context) (*StatsSummary, error)
+	TopDomains(ctx context.Context, blocked bool, count int) ([]TopDomain, error)
+	TopClients(ctx context.Context, blocked bool, count int) ([]TopClient, error)
+	Upstreams(ctx context.Context) ([]Upstream, error)
+}
+
+// QueryService reads Pi-hole's query log
+type QueryService interface {
+	// Each calls fn for every query matching filter, newest first, fetching
+	// the log one page at a time. Iteration stops early when fn returns false.
+	Each(ctx context.Context, filter QueryFilter, fn func(QueryLogEntry) bool) error
+}
+
+// SearchService looks up the allow/deny entries and lists matching a domain
+type SearchService interface {
+	Domain(ctx context.Context, domain string, opts SearchOptions) (*DomainSearchResult, error)
+}
+
+// InfoService reads information about the Pi-hole host and its components
+type InfoService interface {
+	System(ctx context.Context) (*SystemInfo, error)
+	Host(ctx context.Context) (*HostInfo, error)
+	FTL(ctx context.Context) (*FTLInfo, error)
+	Database(ctx context.Context) (*DatabaseInfo, error)
+	Sensors(ctx context.Context) (*SensorsInfo, error)
+	Version(ctx context.Context) (*VersionInfo, error)
+}
+
+// SessionService lists and revokes the API sessions of all clients
+type SessionService interface {
+	List(ctx context.Context) ([]Session, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// ActionService runs one-off actions on Pi-hole. The API only accepts them
+// when webserver.api.allow_destructive is enabled.
+type ActionService interface {
+	// RestartDNS restarts FTL's resolver and waits until the API responds again
+	RestartDNS(ctx context.Context) error
+
+	// FlushLogs empties the query log
+	FlushLogs(ctx context.Context) error
+
+	// FlushARP empties the ARP cache, removing the addresses of all network devices
+	FlushARP(ctx context.Context) error
+
+	// FlushNetwork empties the network table, removing all network devices
+	FlushNetwork(ctx context.Context) error
+}
@@ -1,6 +1,11 @@
 package pihole
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 var (
 	// ErrDNSNotFound is returned when a DNS record is not found
@@ -17,4 +22,71 @@ var (
 
 	// ErrClientNotFound is returned when a client record is not found
 	ErrClientNotFound = errors.New("client not found")
+
+	// ErrNotConsistent is returned when a write is not visible before the
+	// consistency timeout passes
+	ErrNotConsistent = errors.New("change not visible")
 )
+
+// Sentinel errors matched by APIError through errors.Is
+var (
+	// ErrAlreadyExists is matched when Pi-hole rejects a create because the item already exists
+	ErrAlreadyExists = errors.New("item already exists")
+
+	// ErrUnauthorized is matched when Pi-hole rejects the session
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrSeatsExceeded is matched when Pi-hole has no free API sessions left
+	ErrSeatsExceeded = errors.New("API seats exceeded")
+
+	// ErrBadRequest is matched when Pi-hole rejects a request as invalid
+	ErrBadRequest = errors.New("bad request")
+)
+
+// APIError is an error response returned by the Pi-hole API.
+// Pi-hole reports errors as {"error": {"key": ..., "message": ..., "hint": ...}}.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Key is Pi-hole's machine-readable error key, e.g. "bad_request"
+	Key string
+
+	// Message is Pi-hole's human-readable error message
+	Message string
+
+	// Hint is Pi-hole's optional advice on resolving the error
+	Hint string
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+
+	if e.Key == "" {
+		return fmt.Sprintf("%s (status %d)", msg, e.StatusCode)
+	}
+
+	return fmt.Sprintf("%s (status %d, %s)", msg, e.StatusCode, e.Key)
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrAlreadyExists:
+		msg := strings.ToLower(e.Message)
+		return e.StatusCode == http.StatusBadRequest &&
+			(strings.Contains(msg, "already present") || strings.Contains(msg, "duplicate") || strings.Contains(strings.ToLower(e.Hint), "unique constraint"))
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.Key == "unauthorized"
+	case ErrSeatsExceeded:
+		return e.Key == "api_seats_exceeded"
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package fake
+
+import (
+	"net/http"
+	"time"
+)
+
+// SetRestartDowntime makes the API answer 503 for d after each DNS restart,
+// as it is unavailable while FTL restarts
+func (s *Server) SetRestartDowntime(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.restartDowntime = d
+}
+
+// SetRestartInProcess makes DNS restarts reload the resolver in-process, so
+// FTL keeps its PID and uptime
+func (s *Server) SetRestartInProcess(inProcess bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.restartInProcess = inProcess
+}
+
+// Restarts returns the number of DNS restarts
+func (s *Server) Restarts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.restarts
+}
+
+// handleAction runs the action named in the path
+func (s *Server) handleAction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.info.AllowDestructive {
+		writeError(w, http.StatusForbidden, "forbidden", "Destructive actions are disabled", "Enable webserver.api.allow_destructive to use this endpoint")
+		return
+	}
+
+	switch r.PathValue("action") {
+	case "restartdns":
+		// A restarted FTL runs under a new PID with a fresh uptime, unless
+		// it reloaded in-process
+		s.restarts++
+		if !s.restartInProcess {
+			s.info.FTLPID++
+			s.info.FTLUptimeMillis = 0
+		}
+		s.downUntil = time.Now().Add(s.restartDowntime)
+	case "flush/logs":
+		s.queries = nil
+	case "flush/arp":
+		for _, device := range s.devices {
+			device.Addresses = nil
+		}
+	case "flush/network":
+		s.devices = nil
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "Not found", r.URL.Path)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"took":   0.001,
+	})
+}
@@ -0,0 +1,81 @@
+package fake
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Lease is a DHCP lease held by the fake
+type Lease struct {
+	IP       string
+	HWAddr   string
+	Name     string
+	ClientID string
+	Expires  int64
+}
+
+// AddLease adds a DHCP lease
+func (s *Server) AddLease(l Lease) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leases = append(s.leases, l)
+}
+
+// Leases returns the DHCP leases
+func (s *Server) Leases() []Lease {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.leases)
+}
+
+// handleLeases lists the DHCP leases
+func (s *Server) handleLeases(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	leases := make([]map[string]interface{}, 0, len(s.leases))
+	for _, l := range s.leases {
+		leases = append(leases, map[string]interface{}{
+			"expires":  l.Expires,
+			"name":     l.Name,
+			"hwaddr":   l.HWAddr,
+			"ip":       l.IP,
+			"clientid": l.ClientID,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"leases": leases, "took": 0.001})
+}
+
+// handleLease revokes (DELETE) the DHCP lease of an IP address
+func (s *Server) handleLease(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	ip, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/api/dhcp/leases/"))
+	if err != nil || ip == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid IP address", "")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := slices.IndexFunc(s.leases, func(l Lease) bool { return l.IP == ip })
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "not_found", "Lease not found", "")
+		return
+	}
+
+	s.leases = slices.Delete(s.leases, idx, idx+1)
+	w.WriteHeader(http.StatusNoContent)
+}
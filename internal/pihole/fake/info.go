@@ -0,0 +1,272 @@
+package fake
+
+import "net/http"
+
+// Info is the host and FTL information reported by the fake
+type Info struct {
+	Uptime          int64
+	Processes       int
+	MemoryTotal     int64
+	MemoryUsed      int64
+	MemoryAvailable int64
+	SwapTotal       int64
+	SwapUsed        int64
+	CPUCores        int
+	CPUPercent      float64
+	Load            []float64
+
+	Hostname string
+	Sysname  string
+	Release  string
+	Machine  string
+	Model    string
+
+	FTLPID           int
+	FTLUptimeMillis  int64
+	FTLVersion       string
+	CoreVersion      string
+	WebVersion       string
+	PrivacyLevel     int
+	GravityDomains   int
+	AllowDestructive bool
+
+	DatabaseSize      int64
+	DatabaseQueries   int
+	EarliestTimestamp int64
+	SQLiteVersion     string
+
+	CPUTemp  float64
+	HotLimit float64
+	Sensors  []Sensor
+}
+
+// Sensor is a hardware sensor and its temperature readings
+type Sensor struct {
+	Name  string
+	Path  string
+	Temps []SensorTemp
+}
+
+// SensorTemp is a single temperature reading of a sensor
+type SensorTemp struct {
+	Name  string
+	Value float64
+}
+
+// defaultInfo is reported until SetInfo is called
+var defaultInfo = Info{
+	Uptime:          3600,
+	Processes:       120,
+	MemoryTotal:     4000000,
+	MemoryUsed:      1000000,
+	MemoryAvailable: 3000000,
+	CPUCores:        4,
+	Load:            []float64{0.1, 0.2, 0.3},
+
+	Hostname: "pi.hole",
+	Sysname:  "Linux",
+	Release:  "6.1.0",
+	Machine:  "aarch64",
+
+	FTLPID:          42,
+	FTLUptimeMillis: 3600000,
+	FTLVersion:      "v6.0",
+	CoreVersion:     "v6.0",
+	WebVersion:      "v6.0",
+
+	AllowDestructive: true,
+
+	SQLiteVersion: "3.45.1",
+
+	HotLimit: 60,
+}
+
+// SetInfo replaces the host and FTL information
+func (s *Server) SetInfo(info Info) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.info = info
+}
+
+// percent returns part as a percentage of total
+func percent(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
+
+// handleInfoSystem returns the resource usage of the host
+func (s *Server) handleInfoSystem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	in := s.info
+	load := in.Load
+	if load == nil {
+		load = []float64{0, 0, 0}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"system": map[string]interface{}{
+			"uptime": in.Uptime,
+			"memory": map[string]interface{}{
+				"ram": map[string]interface{}{
+					"total":     in.MemoryTotal,
+					"used":      in.MemoryUsed,
+					"free":      in.MemoryTotal - in.MemoryUsed,
+					"available": in.MemoryAvailable,
+					"%used":     percent(in.MemoryUsed, in.MemoryTotal),
+				},
+				"swap": map[string]interface{}{
+					"total": in.SwapTotal,
+					"used":  in.SwapUsed,
+					"free":  in.SwapTotal - in.SwapUsed,
+					"%used": percent(in.SwapUsed, in.SwapTotal),
+				},
+			},
+			"procs": in.Processes,
+			"cpu": map[string]interface{}{
+				"nprocs": in.CPUCores,
+				"%cpu":   in.CPUPercent,
+				"load": map[string]interface{}{
+					"raw":     load,
+					"percent": load,
+				},
+			},
+		},
+		"took": 0.001,
+	})
+}
+
+// handleInfoHost returns the identity of the host
+func (s *Server) handleInfoHost(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	in := s.info
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"host": map[string]interface{}{
+			"uname": map[string]interface{}{
+				"domainname": "(none)",
+				"machine":    in.Machine,
+				"nodename":   in.Hostname,
+				"release":    in.Release,
+				"sysname":    in.Sysname,
+				"version":    "#1 SMP",
+			},
+			"model": nullable(in.Model),
+		},
+		"took": 0.001,
+	})
+}
+
+// handleInfoFTL returns information about the FTL process
+func (s *Server) handleInfoFTL(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	in := s.info
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"ftl": map[string]interface{}{
+			"database": map[string]interface{}{
+				"gravity": in.GravityDomains,
+				"groups":  1,
+				"lists":   1,
+			},
+			"privacy_level":   in.PrivacyLevel,
+			"query_frequency": 0.0,
+			"clients": map[string]interface{}{
+				"total":  len(s.clients),
+				"active": len(s.clients),
+			},
+			"pid":               in.FTLPID,
+			"uptime":            in.FTLUptimeMillis,
+			"%mem":              1.5,
+			"%cpu":              0.5,
+			"allow_destructive": in.AllowDestructive,
+		},
+		"took": 0.001,
+	})
+}
+
+// handleInfoDatabase returns information about the query database
+func (s *Server) handleInfoDatabase(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	in := s.info
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"size":               in.DatabaseSize,
+		"type":               "regular file",
+		"mode":               "rw-r--r--",
+		"atime":              in.EarliestTimestamp,
+		"mtime":              in.EarliestTimestamp,
+		"ctime":              in.EarliestTimestamp,
+		"queries":            in.DatabaseQueries,
+		"earliest_timestamp": in.EarliestTimestamp,
+		"sqlite_version":     in.SQLiteVersion,
+		"took":               0.001,
+	})
+}
+
+// handleInfoSensors returns the temperature readings of the host
+func (s *Server) handleInfoSensors(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	in := s.info
+	list := make([]map[string]interface{}, 0, len(in.Sensors))
+	for _, sensor := range in.Sensors {
+		temps := make([]map[string]interface{}, 0, len(sensor.Temps))
+		for _, t := range sensor.Temps {
+			temps = append(temps, map[string]interface{}{
+				"name":   t.Name,
+				"value":  t.Value,
+				"max":    in.HotLimit,
+				"crit":   in.HotLimit + 20,
+				"sensor": "temp1",
+			})
+		}
+		list = append(list, map[string]interface{}{
+			"name":   sensor.Name,
+			"path":   sensor.Path,
+			"source": sensor.Path,
+			"temps":  temps,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sensors": map[string]interface{}{
+			"list":      list,
+			"cpu_temp":  in.CPUTemp,
+			"hot_limit": in.HotLimit,
+			"unit":      "C",
+		},
+		"took": 0.001,
+	})
+}
+
+// handleInfoVersion returns the versions of the Pi-hole components
+func (s *Server) handleInfoVersion(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	component := func(version string) map[string]interface{} {
+		return map[string]interface{}{
+			"local":  map[string]interface{}{"branch": "master", "version": version, "hash": "abcdef0"},
+			"remote": map[string]interface{}{"version": version, "hash": "abcdef0"},
+		}
+	}
+
+	in := s.info
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"version": map[string]interface{}{
+			"core":   component(in.CoreVersion),
+			"web":    component(in.WebVersion),
+			"ftl":    component(in.FTLVersion),
+			"docker": map[string]interface{}{"local": nil, "remote": nil},
+		},
+		"took": 0.001,
+	})
+}
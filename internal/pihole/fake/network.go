@@ -0,0 +1,121 @@
+package fake
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Device is a network table device held by the fake
+type Device struct {
+	ID         int
+	HWAddr     string
+	Interface  string
+	Vendor     string
+	FirstSeen  int64
+	LastQuery  int64
+	NumQueries int
+	Addresses  []Address
+}
+
+// Address is an IP address of a network table device
+type Address struct {
+	IP          string
+	Name        string
+	LastSeen    int64
+	NameUpdated int64
+}
+
+// AddDevice adds a device to the network table and returns its ID
+func (s *Server) AddDevice(d Device) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d.ID = s.nextDeviceID
+	s.nextDeviceID++
+	d.Addresses = slices.Clone(d.Addresses)
+	s.devices = append(s.devices, &d)
+	return d.ID
+}
+
+// Devices returns the network table devices
+func (s *Server) Devices() []Device {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	devices := make([]Device, 0, len(s.devices))
+	for _, d := range s.devices {
+		devices = append(devices, *d)
+	}
+	return devices
+}
+
+// handleNetworkDevices lists the network table devices
+func (s *Server) handleNetworkDevices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.networkErrors > 0 {
+		s.networkErrors--
+		writeError(w, http.StatusInternalServerError, "database_error", "Could not read network table", "")
+		return
+	}
+
+	devices := make([]map[string]interface{}, 0, len(s.devices))
+	for _, d := range s.devices {
+		ips := make([]map[string]interface{}, 0, len(d.Addresses))
+		for _, a := range d.Addresses {
+			ips = append(ips, map[string]interface{}{
+				"ip":          a.IP,
+				"name":        a.Name,
+				"lastSeen":    a.LastSeen,
+				"nameUpdated": a.NameUpdated,
+			})
+		}
+
+		devices = append(devices, map[string]interface{}{
+			"id":         d.ID,
+			"hwaddr":     d.HWAddr,
+			"interface":  d.Interface,
+			"firstSeen":  d.FirstSeen,
+			"lastQuery":  d.LastQuery,
+			"numQueries": d.NumQueries,
+			"macVendor":  d.Vendor,
+			"ips":        ips,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"devices": devices, "took": 0.001})
+}
+
+// handleNetworkDevice removes (DELETE) a network table device
+func (s *Server) handleNetworkDevice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/network/devices/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid device ID", "")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := slices.IndexFunc(s.devices, func(d *Device) bool { return d.ID == id })
+	if idx < 0 {
+		writeError(w, http.StatusNotFound, "not_found", "Device not found", "")
+		return
+	}
+
+	s.devices = slices.Delete(s.devices, idx, idx+1)
+	w.WriteHeader(http.StatusNoContent)
+}
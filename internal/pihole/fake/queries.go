@@ -0,0 +1,115 @@
+package fake
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+)
+
+// Query is a query log entry held by the fake
+type Query struct {
+	ID         int
+	Time       float64
+	Type       string
+	Domain     string
+	CNAME      string
+	Status     string
+	ClientIP   string
+	ClientName string
+	Upstream   string
+	DNSSEC     string
+	ReplyType  string
+	ReplyTime  float64
+}
+
+// AddQuery appends a query to the log and returns its ID
+func (s *Server) AddQuery(q Query) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q.ID = len(s.queries) + 1
+	s.queries = append(s.queries, q)
+	return q.ID
+}
+
+// handleQueries returns one page of the query log, newest first
+func (s *Server) handleQueries(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	intParam := func(key string, def int) int {
+		if v, err := strconv.Atoi(params.Get(key)); err == nil {
+			return v
+		}
+		return def
+	}
+
+	from := intParam("from", 0)
+	until := intParam("until", 0)
+	start := intParam("start", 0)
+	length := intParam("length", 100)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursor := intParam("cursor", len(s.queries))
+
+	matched := make([]Query, 0)
+	for i := len(s.queries) - 1; i >= 0; i-- {
+		q := s.queries[i]
+		if q.ID > cursor ||
+			(from > 0 && q.Time < float64(from)) ||
+			(until > 0 && q.Time > float64(until)) ||
+			!matchParam(params.Get("client_ip"), q.ClientIP) ||
+			!matchParam(params.Get("client_name"), q.ClientName) ||
+			!matchParam(params.Get("domain"), q.Domain) ||
+			!matchParam(params.Get("upstream"), q.Upstream) ||
+			!matchParam(params.Get("type"), q.Type) ||
+			!matchParam(params.Get("status"), q.Status) {
+			continue
+		}
+		matched = append(matched, q)
+	}
+
+	page := matched[min(start, len(matched)):min(start+length, len(matched))]
+	queries := make([]map[string]interface{}, 0, len(page))
+	for _, q := range page {
+		queries = append(queries, map[string]interface{}{
+			"id":       q.ID,
+			"time":     q.Time,
+			"type":     q.Type,
+			"domain":   q.Domain,
+			"cname":    nullable(q.CNAME),
+			"status":   q.Status,
+			"client":   map[string]interface{}{"ip": q.ClientIP, "name": nullable(q.ClientName)},
+			"dnssec":   q.DNSSEC,
+			"reply":    map[string]interface{}{"type": q.ReplyType, "time": q.ReplyTime},
+			"list_id":  nil,
+			"upstream": nullable(q.Upstream),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"queries":         queries,
+		"cursor":          cursor,
+		"recordsTotal":    len(s.queries),
+		"recordsFiltered": len(matched),
+		"took":            0.001,
+	})
+}
+
+// matchParam reports whether value matches a filter parameter, which may
+// contain * wildcards. An empty parameter matches everything.
+func matchParam(param, value string) bool {
+	if param == "" {
+		return true
+	}
+	ok, _ := path.Match(param, value)
+	return ok
+}
+
+// nullable returns nil for empty strings, which the API reports as null
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
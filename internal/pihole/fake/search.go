@@ -0,0 +1,140 @@
+package fake
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DomainEntry is an exact or regex allow/deny entry held by the fake
+type DomainEntry struct {
+	ID      int
+	Domain  string
+	Type    string // "allow" or "deny"
+	Kind    string // "exact" or "regex"
+	Comment string
+	Enabled bool
+	Groups  []int
+}
+
+// GravityEntry is a domain on an allow or block list held by the fake
+type GravityEntry struct {
+	ID      int
+	Domain  string
+	Address string
+	Type    string // "allow" or "block"
+	Enabled bool
+	Groups  []int
+}
+
+// AddDomainEntry adds an allow/deny entry
+func (s *Server) AddDomainEntry(e DomainEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e.ID = len(s.domainEntries) + 1
+	s.domainEntries = append(s.domainEntries, e)
+}
+
+// AddGravityEntry adds a domain to an allow or block list
+func (s *Server) AddGravityEntry(e GravityEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e.ID = len(s.gravity) + 1
+	s.gravity = append(s.gravity, e)
+}
+
+// handleSearch looks up the entries and lists matching a domain
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	domain, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/api/search/"))
+	if err != nil || domain == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid domain", "")
+		return
+	}
+
+	partial := r.URL.Query().Get("partial") == "true"
+	limit := 20
+	if v, err := strconv.Atoi(r.URL.Query().Get("N")); err == nil {
+		limit = v
+	}
+
+	match := func(entry string) bool {
+		if partial {
+			return strings.Contains(entry, domain)
+		}
+		return entry == domain
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domains := make([]map[string]interface{}, 0)
+	exact, regex := 0, 0
+	for _, e := range s.domainEntries {
+		if e.Kind == "regex" {
+			if ok, _ := regexp.MatchString(e.Domain, domain); !ok {
+				continue
+			}
+			regex++
+		} else {
+			if !match(e.Domain) {
+				continue
+			}
+			exact++
+		}
+
+		if len(domains) < limit {
+			domains = append(domains, map[string]interface{}{
+				"id":      e.ID,
+				"domain":  e.Domain,
+				"type":    e.Type,
+				"kind":    e.Kind,
+				"comment": nullable(e.Comment),
+				"enabled": e.Enabled,
+				"groups":  e.Groups,
+			})
+		}
+	}
+
+	gravity := make([]map[string]interface{}, 0)
+	allow, block := 0, 0
+	for _, e := range s.gravity {
+		if !match(e.Domain) {
+			continue
+		}
+		if e.Type == "allow" {
+			allow++
+		} else {
+			block++
+		}
+
+		if len(gravity) < limit {
+			gravity = append(gravity, map[string]interface{}{
+				"id":      e.ID,
+				"domain":  e.Domain,
+				"address": e.Address,
+				"type":    e.Type,
+				"comment": nil,
+				"enabled": e.Enabled,
+				"groups":  e.Groups,
+			})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"search": map[string]interface{}{
+			"domains": domains,
+			"gravity": gravity,
+			"results": map[string]interface{}{
+				"domains": map[string]interface{}{"exact": exact, "regex": regex},
+				"gravity": map[string]interface{}{"allow": allow, "block": block},
+				"total":   exact + regex + allow + block,
+			},
+			"parameters": map[string]interface{}{"N": limit, "partial": partial, "domain": domain},
+		},
+		"took": 0.001,
+	})
+}
@@ -0,0 +1,824 @@
+// Package fake provides an in-process fake of the Pi-hole v6 API for hermetic
+// tests. It implements the endpoints used by the provider on top of an
+// httptest.Server and offers knobs to inject the failure modes seen against
+// real Pi-hole instances.
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPassword is the admin password accepted by a new Server
+	DefaultPassword = "test"
+
+	// DefaultMaxSessions mirrors Pi-hole's default webserver.api.max_sessions
+	DefaultMaxSessions = 16
+
+	sessionHeader = "X-FTL-SID"
+)
+
+// Session is an API session held by the fake
+type Session struct {
+	ID         string
+	Index      int
+	UserAgent  string
+	LoginAt    int64
+	LastActive int64
+}
+
+// Client is a client configuration held by the fake
+type Client struct {
+	ID           int
+	Client       string
+	Name         string
+	Comment      string
+	Groups       []int
+	DateAdded    int64
+	DateModified int64
+}
+
+// Server is a fake Pi-hole v6 API server
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	password    string
+	maxSessions int
+	sessions    map[string]*Session
+
+	hosts   []string
+	cnames  []string
+	clients []*Client
+	nextID  int
+
+	devices      []*Device
+	nextDeviceID int
+
+	leases []Lease
+	stats  Stats
+
+	queries []Query
+
+	domainEntries []DomainEntry
+	gravity       []GravityEntry
+
+	info             Info
+	restarts         int
+	restartDowntime  time.Duration
+	restartInProcess bool
+	downUntil        time.Time
+
+	// failure injection
+	latency        time.Duration
+	alreadyPresent int
+	unauthorized   int
+	staleReads     int
+	stale          map[string]*staleView
+	networkErrors  int
+
+	requests []string
+}
+
+// NewServer starts a fake Pi-hole accepting DefaultPassword
+func NewServer() *Server {
+	s := &Server{
+		password:    DefaultPassword,
+		maxSessions: DefaultMaxSessions,
+		sessions:    make(map[string]*Session),
+		stale:       make(map[string]*staleView),
+		nextID:      1,
+
+		nextDeviceID: 1,
+
+		info: defaultInfo,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/auth", s.handleAuth)
+	mux.HandleFunc("/api/auth/sessions", s.authenticated(s.handleSessions))
+	mux.HandleFunc("/api/auth/session/", s.authenticated(s.handleSession))
+	mux.HandleFunc("/api/config", s.authenticated(s.handleConfig))
+	mux.HandleFunc("/api/config/dns/hosts", s.authenticated(s.handleList(&s.hosts, "hosts")))
+	mux.HandleFunc("/api/config/dns/hosts/", s.authenticated(s.handleEntry(&s.hosts, "hosts", hostDomain)))
+	mux.HandleFunc("/api/config/dns/cnameRecords", s.authenticated(s.handleList(&s.cnames, "cnameRecords")))
+	mux.HandleFunc("/api/config/dns/cnameRecords/", s.authenticated(s.handleEntry(&s.cnames, "cnameRecords", cnameDomain)))
+	mux.HandleFunc("/api/clients", s.authenticated(s.handleClients))
+	mux.HandleFunc("/api/clients/", s.authenticated(s.handleClient))
+	mux.HandleFunc("/api/network/devices", s.authenticated(s.handleNetworkDevices))
+	mux.HandleFunc("/api/network/devices/", s.authenticated(s.handleNetworkDevice))
+	mux.HandleFunc("/api/dhcp/leases", s.authenticated(s.handleLeases))
+	mux.HandleFunc("/api/dhcp/leases/", s.authenticated(s.handleLease))
+	mux.HandleFunc("GET /api/stats/summary", s.authenticated(s.handleStatsSummary))
+	mux.HandleFunc("GET /api/stats/top_domains", s.authenticated(s.handleTopDomains))
+	mux.HandleFunc("GET /api/stats/top_clients", s.authenticated(s.handleTopClients))
+	mux.HandleFunc("GET /api/stats/upstreams", s.authenticated(s.handleUpstreams))
+	mux.HandleFunc("GET /api/queries", s.authenticated(s.handleQueries))
+	mux.HandleFunc("GET /api/search/", s.authenticated(s.handleSearch))
+	mux.HandleFunc("GET /api/info/system", s.authenticated(s.handleInfoSystem))
+	mux.HandleFunc("GET /api/info/host", s.authenticated(s.handleInfoHost))
+	mux.HandleFunc("GET /api/info/ftl", s.authenticated(s.handleInfoFTL))
+	mux.HandleFunc("GET /api/info/database", s.authenticated(s.handleInfoDatabase))
+	mux.HandleFunc("GET /api/info/sensors", s.authenticated(s.handleInfoSensors))
+	mux.HandleFunc("GET /api/info/version", s.authenticated(s.handleInfoVersion))
+	mux.HandleFunc("POST /api/action/{action...}", s.authenticated(s.handleAction))
+
+	s.Server = httptest.NewServer(s.instrument(mux))
+
+	return s
+}
+
+// SetPassword changes the admin password accepted by the server
+func (s *Server) SetPassword(password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.password = password
+}
+
+// SetMaxSessions changes the number of concurrent sessions allowed before
+// logins are rejected with "API seats exceeded"
+func (s *Server) SetMaxSessions(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxSessions = n
+}
+
+// SetLatency delays every response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailAlreadyPresent makes the next n record creates fail with Pi-hole's
+// "Item already present" error even though the record does not exist, as
+// happens when dnsmasq has not yet processed a prior delete.
+func (s *Server) FailAlreadyPresent(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alreadyPresent = n
+}
+
+// DelayVisibility makes the next n reads of a dns config array after each
+// write to it return the array as it was before the write, as happens while
+// FTL is still applying a change.
+func (s *Server) DelayVisibility(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.staleReads = n
+}
+
+// FailUnauthorized makes the next n authenticated requests fail with 401
+func (s *Server) FailUnauthorized(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unauthorized = n
+}
+
+// FailNetworkTable makes the next n reads of the network table fail with a
+// database error
+func (s *Server) FailNetworkTable(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.networkErrors = n
+}
+
+// AddSession registers a session as if it had been created by a login
+func (s *Server) AddSession(userAgent string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.newSession(userAgent).ID
+}
+
+// AgeSession moves the login and last activity of a session back by d
+func (s *Server) AgeSession(sid string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sess, ok := s.sessions[sid]; ok {
+		sess.LoginAt -= int64(d / time.Second)
+		sess.LastActive -= int64(d / time.Second)
+	}
+}
+
+// Sessions returns the active sessions
+func (s *Server) Sessions() []Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions := make([]Session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		sessions = append(sessions, *sess)
+	}
+	slices.SortFunc(sessions, func(a, b Session) int { return strings.Compare(a.ID, b.ID) })
+	return sessions
+}
+
+// SetHosts replaces the dns.hosts entries ("IP domain")
+func (s *Server) SetHosts(hosts ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hosts = slices.Clone(hosts)
+}
+
+// Hosts returns the dns.hosts entries
+func (s *Server) Hosts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.hosts)
+}
+
+// SetCNAMEs replaces the dns.cnameRecords entries ("domain,target")
+func (s *Server) SetCNAMEs(cnames ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cnames = slices.Clone(cnames)
+}
+
+// CNAMEs returns the dns.cnameRecords entries
+func (s *Server) CNAMEs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.cnames)
+}
+
+// AddClient adds a client configuration
+func (s *Server) AddClient(client, comment string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addClient(client, comment, nil)
+}
+
+// Clients returns the client configurations
+func (s *Server) Clients() []Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clients := make([]Client, 0, len(s.clients))
+	for _, c := range s.clients {
+		clients = append(clients, *c)
+	}
+	return clients
+}
+
+// Requests returns "METHOD path" for every request received so far
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// instrument records requests, applies the configured latency and rejects
+// requests while FTL is restarting
+func (s *Server) instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		latency := s.latency
+		down := time.Now().Before(s.downUntil)
+		s.mu.Unlock()
+
+		if down {
+			writeError(w, http.StatusServiceUnavailable, "restarting", "FTL is restarting", "")
+			return
+		}
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticated rejects requests without a valid session ID
+func (s *Server) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		sess, ok := s.sessions[r.Header.Get(sessionHeader)]
+		if s.unauthorized > 0 {
+			s.unauthorized--
+			ok = false
+		}
+		if ok {
+			sess.LastActive = time.Now().Unix()
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized", "")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// handleAuth implements login (POST) and logout (DELETE)
+func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPost:
+		var body struct {
+			Password string `json:"password"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", "Invalid request body data (no valid JSON)", "")
+			return
+		}
+
+		if body.Password != s.password {
+			writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+				"session": map[string]interface{}{
+					"valid":    false,
+					"totp":     false,
+					"sid":      nil,
+					"validity": -1,
+					"message":  "password incorrect",
+				},
+			})
+			return
+		}
+
+		if len(s.sessions) >= s.maxSessions {
+			writeError(w, http.StatusTooManyRequests, "api_seats_exceeded", "API seats exceeded", "increase webserver.api.max_sessions")
+			return
+		}
+
+		sess := s.newSession(r.UserAgent())
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"session": map[string]interface{}{
+				"valid":    true,
+				"totp":     false,
+				"sid":      sess.ID,
+				"csrf":     sess.ID,
+				"validity": 1800,
+				"message":  "password correct",
+			},
+		})
+	case http.MethodDelete:
+		sid := r.Header.Get(sessionHeader)
+		if _, ok := s.sessions[sid]; !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized", "")
+			return
+		}
+		delete(s.sessions, sid)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleSessions lists active sessions
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := make([]*Session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		active = append(active, sess)
+	}
+	slices.SortFunc(active, func(a, b *Session) int { return a.Index - b.Index })
+
+	current := r.Header.Get(sessionHeader)
+	sessions := make([]map[string]interface{}, 0, len(active))
+	for _, sess := range active {
+		sessions = append(sessions, map[string]interface{}{
+			"id":              sess.Index,
+			"current_session": sess.ID == current,
+			"valid":           true,
+			"app":             false,
+			"cli":             false,
+			"login_at":        sess.LoginAt,
+			"last_active":     sess.LastActive,
+			"valid_until":     sess.LastActive + 1800,
+			"remote_addr":     "127.0.0.1",
+			"user_agent":      sess.UserAgent,
+			"x_forwarded_for": nil,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"sessions": sessions})
+}
+
+// handleSession revokes a single session by the ID the sessions API reports
+func (s *Server) handleSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	index, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/auth/session/"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid session ID", "")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sid, sess := range s.sessions {
+		if sess.Index == index {
+			delete(s.sessions, sid)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "Session not found", "")
+}
+
+// handleConfig implements PATCH /api/config for the dns.hosts and
+// dns.cnameRecords arrays
+func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		Config struct {
+			DNS struct {
+				Hosts        *[]string `json:"hosts"`
+				CNAMERecords *[]string `json:"cnameRecords"`
+			} `json:"dns"`
+		} `json:"config"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid request body data (no valid JSON)", "")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if body.Config.DNS.Hosts != nil {
+		s.hide("hosts", s.hosts)
+		s.hosts = *body.Config.DNS.Hosts
+	}
+	if body.Config.DNS.CNAMERecords != nil {
+		s.hide("cnameRecords", s.cnames)
+		s.cnames = *body.Config.DNS.CNAMERecords
+	}
+
+	writeJSON(w, http.StatusOK, s.dnsConfig())
+}
+
+// handleList returns one of the dns config arrays
+func (s *Server) handleList(list *[]string, key string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		entries := *list
+		if v := s.stale[key]; v != nil && v.reads > 0 {
+			v.reads--
+			entries = v.entries
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"config": map[string]interface{}{
+				"dns": map[string]interface{}{key: entries},
+			},
+		})
+	}
+}
+
+// handleEntry adds (PUT) or removes (DELETE) one entry of a dns config array
+func (s *Server) handleEntry(list *[]string, key string, domainOf func(string) string) http.HandlerFunc {
+	prefix := "/api/config/dns/" + key + "/"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		entry, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), prefix))
+		if err != nil || entry == "" {
+			writeError(w, http.StatusBadRequest, "bad_request", "Invalid item", "")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			if s.alreadyPresent > 0 {
+				s.alreadyPresent--
+				writeError(w, http.StatusBadRequest, "bad_request", "Item already present", "Uniqueness of items is enforced")
+				return
+			}
+
+			domain := domainOf(entry)
+			for _, e := range *list {
+				if e == entry || (key == "cnameRecords" && domainOf(e) == domain) {
+					writeError(w, http.StatusBadRequest, "bad_request", "Item already present", "Uniqueness of items is enforced")
+					return
+				}
+			}
+
+			s.hide(key, *list)
+			*list = append(*list, entry)
+			writeJSON(w, http.StatusCreated, s.dnsConfig())
+		case http.MethodDelete:
+			idx := slices.Index(*list, entry)
+			if idx < 0 {
+				writeError(w, http.StatusNotFound, "not_found", "Item not found", "")
+				return
+			}
+
+			s.hide(key, *list)
+			*list = slices.Delete(*list, idx, idx+1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// staleView is the outdated contents of a dns config array served while a
+// write to it is not yet visible
+type staleView struct {
+	entries []string
+	reads   int
+}
+
+// hide keeps serving entries for the array key for the next reads configured
+// with DelayVisibility. Callers must hold s.mu.
+func (s *Server) hide(key string, entries []string) {
+	if s.staleReads <= 0 {
+		return
+	}
+
+	// Writes made while an earlier one is still hidden stay hidden behind it
+	if v := s.stale[key]; v != nil && v.reads > 0 {
+		v.reads = s.staleReads
+		return
+	}
+
+	s.stale[key] = &staleView{entries: slices.Clone(entries), reads: s.staleReads}
+}
+
+// handleClients lists (GET) or creates (POST) clients
+func (s *Server) handleClients(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.clientsResponse(s.clients...))
+	case http.MethodPost:
+		var body struct {
+			Client  string `json:"client"`
+			Comment string `json:"comment"`
+			Groups  []int  `json:"groups"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Client == "" {
+			writeError(w, http.StatusBadRequest, "bad_request", "Invalid request body data", "")
+			return
+		}
+
+		if s.findClient(body.Client) != nil {
+			writeError(w, http.StatusBadRequest, "database_error", "Could not add to gravity database", "UNIQUE constraint failed: client.ip")
+			return
+		}
+
+		c := s.addClient(body.Client, body.Comment, body.Groups)
+		writeJSON(w, http.StatusCreated, s.clientsResponse(c))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleClient reads (GET), updates (PUT) or removes (DELETE) a client
+func (s *Server) handleClient(w http.ResponseWriter, r *http.Request) {
+	id, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/api/clients/"))
+	if err != nil || id == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid client", "")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findClient(id)
+
+	switch r.Method {
+	case http.MethodGet:
+		if c == nil {
+			writeJSON(w, http.StatusOK, s.clientsResponse())
+			return
+		}
+		writeJSON(w, http.StatusOK, s.clientsResponse(c))
+	case http.MethodPut:
+		var body struct {
+			Comment *string `json:"comment"`
+			Groups  []int   `json:"groups"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", "Invalid request body data", "")
+			return
+		}
+
+		if c == nil {
+			c = s.addClient(id, "", nil)
+		}
+		if body.Comment != nil {
+			c.Comment = *body.Comment
+		}
+		if body.Groups != nil {
+			c.Groups = body.Groups
+		}
+		c.DateModified = time.Now().Unix()
+
+		writeJSON(w, http.StatusOK, s.clientsResponse(c))
+	case http.MethodDelete:
+		if c == nil {
+			writeError(w, http.StatusNotFound, "not_found", "Item not found", "")
+			return
+		}
+
+		s.clients = slices.DeleteFunc(s.clients, func(e *Client) bool { return e == c })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// newSession creates a session. Callers must hold s.mu.
+func (s *Server) newSession(userAgent string) *Session {
+	// Pi-hole identifies sessions in its API by their slot
+	taken := make(map[int]bool, len(s.sessions))
+	for _, sess := range s.sessions {
+		taken[sess.Index] = true
+	}
+	index := 0
+	for taken[index] {
+		index++
+	}
+
+	now := time.Now().Unix()
+	sess := &Session{
+		ID:         randomID(),
+		Index:      index,
+		UserAgent:  userAgent,
+		LoginAt:    now,
+		LastActive: now,
+	}
+	s.sessions[sess.ID] = sess
+	return sess
+}
+
+// addClient adds a client. Callers must hold s.mu.
+func (s *Server) addClient(client, comment string, groups []int) *Client {
+	if groups == nil {
+		groups = []int{0}
+	}
+
+	now := time.Now().Unix()
+	c := &Client{
+		ID:           s.nextID,
+		Client:       canonicalClient(client),
+		Comment:      comment,
+		Groups:       groups,
+		DateAdded:    now,
+		DateModified: now,
+	}
+	s.nextID++
+	s.clients = append(s.clients, c)
+	return c
+}
+
+// findClient looks up a client by identifier. Callers must hold s.mu.
+func (s *Server) findClient(client string) *Client {
+	client = canonicalClient(client)
+	for _, c := range s.clients {
+		if c.Client == client {
+			return c
+		}
+	}
+	return nil
+}
+
+// canonicalClient returns a client identifier the way Pi-hole stores it,
+// with MAC addresses in lower case. Dotted MAC addresses are hostnames.
+func canonicalClient(client string) string {
+	if mac, err := net.ParseMAC(client); err == nil && !strings.Contains(client, ".") {
+		return mac.String()
+	}
+	return client
+}
+
+// clientName resolves the hostname of a client from the network table, like
+// Pi-hole does for IP and MAC clients. Callers must hold s.mu.
+func (s *Server) clientName(client string) string {
+	for _, d := range s.devices {
+		for _, a := range d.Addresses {
+			if a.Name != "" && (a.IP == client || canonicalClient(d.HWAddr) == client) {
+				return a.Name
+			}
+		}
+	}
+	return ""
+}
+
+// dnsConfig returns the config object for the dns arrays. Callers must hold s.mu.
+func (s *Server) dnsConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"config": map[string]interface{}{
+			"dns": map[string]interface{}{
+				"hosts":        s.hosts,
+				"cnameRecords": s.cnames,
+			},
+		},
+	}
+}
+
+// clientsResponse renders clients the way /api/clients does. Callers must
+// hold s.mu.
+func (s *Server) clientsResponse(clients ...*Client) map[string]interface{} {
+	list := make([]map[string]interface{}, 0, len(clients))
+	for _, c := range clients {
+		name := c.Name
+		if name == "" {
+			name = s.clientName(c.Client)
+		}
+
+		list = append(list, map[string]interface{}{
+			"client":        c.Client,
+			"name":          nullable(name),
+			"comment":       c.Comment,
+			"groups":        c.Groups,
+			"id":            c.ID,
+			"date_added":    c.DateAdded,
+			"date_modified": c.DateModified,
+		})
+	}
+	return map[string]interface{}{"clients": list}
+}
+
+// writeError writes Pi-hole's JSON error envelope
+func writeError(w http.ResponseWriter, status int, key, message, hint string) {
+	var h interface{}
+	if hint != "" {
+		h = hint
+	}
+
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"key":     key,
+			"message": message,
+			"hint":    h,
+		},
+	})
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// hostDomain returns the domain of an "IP domain" entry
+func hostDomain(entry string) string {
+	_, domain, _ := strings.Cut(entry, " ")
+	return domain
+}
+
+// cnameDomain returns the domain of a "domain,target" entry
+func cnameDomain(entry string) string {
+	domain, _, _ := strings.Cut(entry, ",")
+	return domain
+}
+
+// randomID returns a random session ID
+func randomID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
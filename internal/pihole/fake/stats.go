@@ -0,0 +1,172 @@
+package fake
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Stats are the query statistics reported by the fake
+type Stats struct {
+	TotalQueries        int
+	BlockedQueries      int
+	UniqueDomains       int
+	ForwardedQueries    int
+	CachedQueries       int
+	QueryTypes          map[string]int
+	ActiveClients       int
+	TotalClients        int
+	DomainsBeingBlocked int
+	GravityLastUpdate   int64
+
+	TopDomains        []DomainCount
+	TopBlocked        []DomainCount
+	TopClients        []ClientCount
+	TopBlockedClients []ClientCount
+	Upstreams         []Upstream
+}
+
+// DomainCount is a domain and its number of queries
+type DomainCount struct {
+	Domain string
+	Count  int
+}
+
+// ClientCount is a client and its number of queries
+type ClientCount struct {
+	IP    string
+	Name  string
+	Count int
+}
+
+// Upstream is an upstream destination and its statistics
+type Upstream struct {
+	IP       string
+	Name     string
+	Port     int
+	Count    int
+	Response float64
+	Variance float64
+}
+
+// SetStats replaces the query statistics
+func (s *Server) SetStats(st Stats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats = st
+}
+
+// handleStatsSummary returns the statistics summary
+func (s *Server) handleStatsSummary(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.stats
+	percent := 0.0
+	if st.TotalQueries > 0 {
+		percent = float64(st.BlockedQueries) * 100 / float64(st.TotalQueries)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"queries": map[string]interface{}{
+			"total":           st.TotalQueries,
+			"blocked":         st.BlockedQueries,
+			"percent_blocked": percent,
+			"unique_domains":  st.UniqueDomains,
+			"forwarded":       st.ForwardedQueries,
+			"cached":          st.CachedQueries,
+			"frequency":       float64(st.TotalQueries) / 86400,
+			"types":           st.QueryTypes,
+		},
+		"clients": map[string]interface{}{
+			"active": st.ActiveClients,
+			"total":  st.TotalClients,
+		},
+		"gravity": map[string]interface{}{
+			"domains_being_blocked": st.DomainsBeingBlocked,
+			"last_update":           st.GravityLastUpdate,
+		},
+		"took": 0.001,
+	})
+}
+
+// handleTopDomains returns the most queried blocked or permitted domains
+func (s *Server) handleTopDomains(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ranked := s.stats.TopDomains
+	if r.URL.Query().Get("blocked") == "true" {
+		ranked = s.stats.TopBlocked
+	}
+
+	domains := make([]map[string]interface{}, 0, len(ranked))
+	for _, d := range limit(ranked, r) {
+		domains = append(domains, map[string]interface{}{"domain": d.Domain, "count": d.Count})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"domains":         domains,
+		"total_queries":   s.stats.TotalQueries,
+		"blocked_queries": s.stats.BlockedQueries,
+		"took":            0.001,
+	})
+}
+
+// handleTopClients returns the clients sending the most (blocked) queries
+func (s *Server) handleTopClients(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ranked := s.stats.TopClients
+	if r.URL.Query().Get("blocked") == "true" {
+		ranked = s.stats.TopBlockedClients
+	}
+
+	clients := make([]map[string]interface{}, 0, len(ranked))
+	for _, c := range limit(ranked, r) {
+		clients = append(clients, map[string]interface{}{"ip": c.IP, "name": c.Name, "count": c.Count})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"clients":         clients,
+		"total_queries":   s.stats.TotalQueries,
+		"blocked_queries": s.stats.BlockedQueries,
+		"took":            0.001,
+	})
+}
+
+// handleUpstreams returns the upstream destinations
+func (s *Server) handleUpstreams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upstreams := make([]map[string]interface{}, 0, len(s.stats.Upstreams))
+	for _, u := range s.stats.Upstreams {
+		upstreams = append(upstreams, map[string]interface{}{
+			"ip":    u.IP,
+			"name":  u.Name,
+			"port":  u.Port,
+			"count": u.Count,
+			"statistics": map[string]interface{}{
+				"response": u.Response,
+				"variance": u.Variance,
+			},
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"upstreams":         upstreams,
+		"forwarded_queries": s.stats.ForwardedQueries,
+		"total_queries":     s.stats.TotalQueries,
+		"took":              0.001,
+	})
+}
+
+// limit truncates items to the count query parameter, which defaults to 10
+func limit[T any](items []T, r *http.Request) []T {
+	count := 10
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil {
+		count = v
+	}
+	return items[:min(count, len(items))]
+}
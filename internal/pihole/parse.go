@@ -0,0 +1,182 @@
+package pihole
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DomainRegex matches valid domain names.
+// Allows alphanumeric characters, hyphens, underscores, and dots.
+// Each label must start and end with alphanumeric characters.
+var DomainRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9_-]*[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9_-]*[a-zA-Z0-9])?$`)
+
+// MaxDomainLength is the maximum length of a domain name
+const MaxDomainLength = 253
+
+// ValidDomain reports whether domain is a domain name Pi-hole accepts for
+// local DNS and CNAME records
+func ValidDomain(domain string) bool {
+	return len(domain) >= 1 && len(domain) <= MaxDomainLength && DomainRegex.MatchString(domain)
+}
+
+// NormalizeDomain returns domain in the form Pi-hole stores it: without
+// surrounding whitespace or a trailing dot, and in lower case
+func NormalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+}
+
+// HasDomainSuffix reports whether domain is suffix or one of its subdomains.
+// Both are compared in normalised form, and suffix may start with a dot.
+func HasDomainSuffix(domain, suffix string) bool {
+	domain = NormalizeDomain(domain)
+	suffix = strings.TrimPrefix(NormalizeDomain(suffix), ".")
+	return suffix == "" || domain == suffix || strings.HasSuffix(domain, "."+suffix)
+}
+
+// ParseHostsLine parses a line in hosts file format, "IP name [name...]",
+// as used by the dns.hosts setting, Pi-hole v5's custom.list and
+// /etc/hosts. It returns a record for every name, or none for blank and
+// comment lines. The IP address is not validated.
+func ParseHostsLine(line string) []DNSRecord {
+	fields := strings.Fields(stripComment(line))
+	if len(fields) < 2 {
+		return nil
+	}
+
+	records := make([]DNSRecord, 0, len(fields)-1)
+	for _, name := range fields[1:] {
+		records = append(records, DNSRecord{IP: fields[0], Domain: name})
+	}
+	return records
+}
+
+// ParseCNAMELine parses a CNAME entry, "domain[,domain...],target[,ttl]",
+// as used by the dns.cnameRecords setting. The "cname=" prefix of dnsmasq
+// configuration files such as Pi-hole v5's 05-pihole-custom-cname.conf is
+// accepted too. It returns a record for every domain, or none for blank and
+// comment lines.
+func ParseCNAMELine(line string) []CNAMERecord {
+	line = strings.TrimSpace(stripComment(line))
+	line = strings.TrimPrefix(line, "cname=")
+
+	fields := strings.Split(line, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	// An optional TTL follows the target
+	if n := len(fields); n > 2 {
+		if _, err := strconv.ParseUint(fields[n-1], 10, 32); err == nil {
+			fields = fields[:n-1]
+		}
+	}
+	if len(fields) < 2 {
+		return nil
+	}
+
+	target := fields[len(fields)-1]
+	records := make([]CNAMERecord, 0, len(fields)-1)
+	for _, domain := range fields[:len(fields)-1] {
+		records = append(records, CNAMERecord{Domain: domain, Target: target})
+	}
+	return records
+}
+
+// stripComment removes a trailing # comment from line
+func stripComment(line string) string {
+	line, _, _ = strings.Cut(line, "#")
+	return line
+}
+
+// ClientType is the kind of identifier a Pi-hole client is configured with
+type ClientType string
+
+// Kinds of client identifiers
+const (
+	ClientIPv4      ClientType = "ipv4"
+	ClientIPv6      ClientType = "ipv6"
+	ClientCIDR      ClientType = "cidr"
+	ClientMAC       ClientType = "mac"
+	ClientHostname  ClientType = "hostname"
+	ClientInterface ClientType = "interface"
+)
+
+// interfaceRegex matches Linux interface names, which are at most 15 characters
+var interfaceRegex = regexp.MustCompile(`^[a-zA-Z0-9_.@-]{1,15}$`)
+
+// ParseClientID classifies a client identifier and returns it in a canonical
+// form: MAC addresses in lower case separated by colons, IPv6 addresses
+// compressed, CIDR ranges reduced to their network address and hostnames in
+// lower case. Interfaces are identified by their name prefixed with a colon.
+// MAC addresses in the dotted form aabb.ccdd.eeff are valid hostnames too and
+// are classified as hostnames, which is how Pi-hole looks them up. Two
+// identifiers refer to the same client if their canonical forms match.
+func ParseClientID(client string) (ClientType, string, error) {
+	if name, ok := strings.CutPrefix(client, ":"); ok {
+		if !interfaceRegex.MatchString(name) {
+			return "", "", fmt.Errorf("%q is not a valid interface name", name)
+		}
+		return ClientInterface, client, nil
+	}
+
+	if strings.Contains(client, "/") {
+		prefix, err := netip.ParsePrefix(client)
+		if err != nil {
+			return "", "", fmt.Errorf("%q is not a valid CIDR range", client)
+		}
+		return ClientCIDR, prefix.Masked().String(), nil
+	}
+
+	if addr, err := netip.ParseAddr(client); err == nil {
+		if addr.Is4() {
+			return ClientIPv4, addr.String(), nil
+		}
+		return ClientIPv6, addr.String(), nil
+	}
+
+	if mac, err := net.ParseMAC(client); err == nil && len(mac) == 6 && !strings.Contains(client, ".") {
+		return ClientMAC, mac.String(), nil
+	}
+
+	if ValidDomain(client) {
+		return ClientHostname, strings.ToLower(client), nil
+	}
+
+	return "", "", fmt.Errorf("%q is not an IP address, CIDR range, MAC address, hostname or interface (such as \":eth0\")", client)
+}
+
+// ClientDevice returns the network table device a client identifier refers
+// to: the device with the hardware address of a MAC client, or the device
+// last seen with the address of an IP client. Other kinds of clients can
+// cover several devices and refer to none.
+func ClientDevice(devices []NetworkDevice, client string) (NetworkDevice, bool) {
+	kind, canonical, err := ParseClientID(client)
+	if err != nil {
+		return NetworkDevice{}, false
+	}
+
+	var (
+		found    NetworkDevice
+		lastSeen int64 = -1
+	)
+	for _, d := range devices {
+		switch kind {
+		case ClientMAC:
+			if mac, err := net.ParseMAC(d.HWAddr); err == nil && mac.String() == canonical {
+				return d, true
+			}
+		case ClientIPv4, ClientIPv6:
+			for _, a := range d.Addresses {
+				if addr, err := netip.ParseAddr(a.IP); err == nil && addr.String() == canonical && a.LastSeen > lastSeen {
+					found, lastSeen = d, a.LastSeen
+				}
+			}
+		}
+	}
+
+	return found, lastSeen >= 0
+}
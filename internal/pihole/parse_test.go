@@ -0,0 +1,156 @@
+package pihole
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHostsLine(t *testing.T) {
+	cases := []struct {
+		line     string
+		expected []DNSRecord
+	}{
+		{line: "10.0.0.1 a.lan", expected: []DNSRecord{{IP: "10.0.0.1", Domain: "a.lan"}}},
+		{line: "  10.0.0.1\ta.lan   b.lan # router", expected: []DNSRecord{{IP: "10.0.0.1", Domain: "a.lan"}, {IP: "10.0.0.1", Domain: "b.lan"}}},
+		{line: "fd00::1 c.lan", expected: []DNSRecord{{IP: "fd00::1", Domain: "c.lan"}}},
+		{line: "# 10.0.0.1 a.lan"},
+		{line: "10.0.0.1"},
+		{line: ""},
+	}
+
+	for _, tc := range cases {
+		if records := ParseHostsLine(tc.line); !reflect.DeepEqual(records, tc.expected) {
+			t.Errorf("ParseHostsLine(%q) = %v, expected %v", tc.line, records, tc.expected)
+		}
+	}
+}
+
+func TestParseCNAMELine(t *testing.T) {
+	cases := []struct {
+		line     string
+		expected []CNAMERecord
+	}{
+		{line: "b.lan,a.lan", expected: []CNAMERecord{{Domain: "b.lan", Target: "a.lan"}}},
+		{line: "b.lan,a.lan,300", expected: []CNAMERecord{{Domain: "b.lan", Target: "a.lan"}}},
+		{line: "cname=b.lan, c.lan ,a.lan # aliases", expected: []CNAMERecord{{Domain: "b.lan", Target: "a.lan"}, {Domain: "c.lan", Target: "a.lan"}}},
+		{line: "b.lan,300", expected: []CNAMERecord{{Domain: "b.lan", Target: "300"}}},
+		{line: "# b.lan,a.lan"},
+		{line: "b.lan"},
+		{line: ""},
+	}
+
+	for _, tc := range cases {
+		if records := ParseCNAMELine(tc.line); !reflect.DeepEqual(records, tc.expected) {
+			t.Errorf("ParseCNAMELine(%q) = %v, expected %v", tc.line, records, tc.expected)
+		}
+	}
+}
+
+func TestDomains(t *testing.T) {
+	cases := []struct {
+		domain     string
+		valid      bool
+		normalized string
+	}{
+		{domain: "a.lan", valid: true, normalized: "a.lan"},
+		{domain: "My_Host.LAN", valid: true, normalized: "my_host.lan"},
+		{domain: " a.lan. ", valid: false, normalized: "a.lan"},
+		{domain: "-a.lan", valid: false, normalized: "-a.lan"},
+		{domain: "", valid: false, normalized: ""},
+	}
+
+	for _, tc := range cases {
+		if valid := ValidDomain(tc.domain); valid != tc.valid {
+			t.Errorf("ValidDomain(%q) = %t, expected %t", tc.domain, valid, tc.valid)
+		}
+		if normalized := NormalizeDomain(tc.domain); normalized != tc.normalized {
+			t.Errorf("NormalizeDomain(%q) = %q, expected %q", tc.domain, normalized, tc.normalized)
+		}
+	}
+}
+
+func TestParseClientID(t *testing.T) {
+	cases := []struct {
+		client    string
+		kind      ClientType
+		canonical string
+	}{
+		{client: "192.168.1.10", kind: ClientIPv4, canonical: "192.168.1.10"},
+		{client: "FD00:0::1", kind: ClientIPv6, canonical: "fd00::1"},
+		{client: "192.168.1.10/24", kind: ClientCIDR, canonical: "192.168.1.0/24"},
+		{client: "fd00::/64", kind: ClientCIDR, canonical: "fd00::/64"},
+		{client: "AA-BB-CC-DD-EE-FF", kind: ClientMAC, canonical: "aa:bb:cc:dd:ee:ff"},
+		{client: "Laptop.lan", kind: ClientHostname, canonical: "laptop.lan"},
+		// The dotted MAC address form is a hostname as well, and Pi-hole
+		// looks it up as one
+		{client: "AABB.CCDD.EEFF", kind: ClientHostname, canonical: "aabb.ccdd.eeff"},
+		{client: ":eth0", kind: ClientInterface, canonical: ":eth0"},
+		{client: "192.168.1.0/33"},
+		{client: "laptop lan"},
+		{client: ":"},
+		{client: "-laptop"},
+		{client: ""},
+	}
+
+	for _, tc := range cases {
+		kind, canonical, err := ParseClientID(tc.client)
+		if tc.kind == "" {
+			if err == nil {
+				t.Errorf("ParseClientID(%q) = %s %q, expected an error", tc.client, kind, canonical)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseClientID(%q): %s", tc.client, err)
+		} else if kind != tc.kind || canonical != tc.canonical {
+			t.Errorf("ParseClientID(%q) = %s %q, expected %s %q", tc.client, kind, canonical, tc.kind, tc.canonical)
+		}
+	}
+}
+
+func TestClientDevice(t *testing.T) {
+	devices := []NetworkDevice{
+		{ID: 1, HWAddr: "aa:bb:cc:dd:ee:01", Addresses: []NetworkAddress{{IP: "192.168.1.10", LastSeen: 100}}},
+		{ID: 2, HWAddr: "aa:bb:cc:dd:ee:02", Addresses: []NetworkAddress{{IP: "192.168.1.10", LastSeen: 200}, {IP: "fd00::2", LastSeen: 50}}},
+	}
+
+	cases := []struct {
+		client   string
+		expected int
+	}{
+		{"AA-BB-CC-DD-EE-01", 1},
+		{"192.168.1.10", 2},
+		{"fd00:0::2", 2},
+		{"192.168.1.0/24", 0},
+		{"192.168.1.11", 0},
+		{":eth0", 0},
+	}
+
+	for _, tc := range cases {
+		d, ok := ClientDevice(devices, tc.client)
+		if ok != (tc.expected != 0) || d.ID != tc.expected {
+			t.Errorf("ClientDevice(%q) = %d %t, expected %d", tc.client, d.ID, ok, tc.expected)
+		}
+	}
+}
+
+func TestHasDomainSuffix(t *testing.T) {
+	cases := []struct {
+		domain   string
+		suffix   string
+		expected bool
+	}{
+		{domain: "nas.home.lan", suffix: "home.lan", expected: true},
+		{domain: "home.lan", suffix: "home.lan", expected: true},
+		{domain: "NAS.Home.LAN", suffix: ".home.lan.", expected: true},
+		{domain: "nas.myhome.lan", suffix: "home.lan", expected: false},
+		{domain: "nas.lan", suffix: "", expected: true},
+	}
+
+	for _, tc := range cases {
+		if result := HasDomainSuffix(tc.domain, tc.suffix); result != tc.expected {
+			t.Errorf("HasDomainSuffix(%q, %q) = %t, expected %t", tc.domain, tc.suffix, result, tc.expected)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package pihole
 
+import "time"
+
 // DNSRecord represents a local DNS A record
 type DNSRecord struct {
 	Domain string
@@ -23,9 +25,345 @@ type ClientRecord struct {
 	DateModified int64
 }
 
+// NetworkDevice is a device in Pi-hole's network table, which records every
+// device that has queried Pi-hole or been seen in its ARP cache
+type NetworkDevice struct {
+	ID         int
+	HWAddr     string
+	Interface  string
+	Vendor     string
+	FirstSeen  int64
+	LastQuery  int64
+	NumQueries int
+	Addresses  []NetworkAddress
+}
+
+// NetworkAddress is an IP address a network device has been seen with
+type NetworkAddress struct {
+	IP          string
+	Name        string
+	LastSeen    int64
+	NameUpdated int64
+}
+
+// LastSeen returns when the device was last active, either by sending a
+// query or by being seen with one of its addresses
+func (d NetworkDevice) LastSeen() int64 {
+	last := d.LastQuery
+	for _, a := range d.Addresses {
+		last = max(last, a.LastSeen)
+	}
+	return last
+}
+
+// DHCPLease is a lease handed out by Pi-hole's DHCP server
+type DHCPLease struct {
+	IP       string
+	HWAddr   string
+	Name     string
+	ClientID string
+
+	// Expires is when the lease ends, or 0 for infinite leases
+	Expires int64
+}
+
+// StatsSummary is Pi-hole's overview of the queries it has answered
+type StatsSummary struct {
+	TotalQueries     int
+	BlockedQueries   int
+	PercentBlocked   float64
+	UniqueDomains    int
+	ForwardedQueries int
+	CachedQueries    int
+
+	// QueryFrequency is the number of queries per second
+	QueryFrequency float64
+
+	// QueryTypes counts queries by record type (A, AAAA, ...)
+	QueryTypes map[string]int
+
+	ActiveClients int
+	TotalClients  int
+
+	DomainsBeingBlocked int
+	GravityLastUpdate   int64
+}
+
+// TopDomain is a domain ranked by the number of queries for it
+type TopDomain struct {
+	Domain string
+	Count  int
+}
+
+// TopClient is a client ranked by the number of queries it sent
+type TopClient struct {
+	IP    string
+	Name  string
+	Count int
+}
+
+// Upstream is a destination Pi-hole answered queries from, including the
+// pseudo upstreams "blocklist" and "cache"
+type Upstream struct {
+	IP    string
+	Name  string
+	Port  int
+	Count int
+
+	// ResponseTime and ResponseVariance are in milliseconds
+	ResponseTime     float64
+	ResponseVariance float64
+}
+
+// QueryLogEntry is a query from Pi-hole's query log
+type QueryLogEntry struct {
+	ID int
+
+	// Time is when the query was received, in fractional Unix seconds
+	Time float64
+
+	Type       string
+	Domain     string
+	CNAME      string
+	Status     string
+	ClientIP   string
+	ClientName string
+	Upstream   string
+	DNSSEC     string
+	ReplyType  string
+
+	// ReplyTime is how long the reply took, in milliseconds
+	ReplyTime float64
+}
+
+// QueryFilter selects entries of the query log. Zero values match every query.
+type QueryFilter struct {
+	// From and Until bound the query time, in Unix seconds
+	From  int64
+	Until int64
+
+	ClientIP   string
+	ClientName string
+
+	// Domain may contain * wildcards
+	Domain string
+
+	Upstream string
+	Type     string
+	Status   string
+
+	// Cursor is the ID of the newest query to include, which keeps paging
+	// stable while new queries arrive. Zero starts at the newest query.
+	Cursor int
+}
+
+// SearchOptions controls a domain search
+type SearchOptions struct {
+	// Partial also matches domains containing the search term
+	Partial bool
+
+	// Limit caps the number of matches returned per kind. Zero uses the API
+	// default of 20.
+	Limit int
+}
+
+// DomainSearchResult lists the allow/deny entries and gravity lists matching
+// a domain
+type DomainSearchResult struct {
+	Domains []DomainMatch
+	Gravity []GravityMatch
+
+	// Total numbers of matches, which may exceed the entries returned
+	ExactMatches        int
+	RegexMatches        int
+	GravityAllowMatches int
+	GravityBlockMatches int
+}
+
+// Blocked reports whether Pi-hole blocks domain according to the matches,
+// applying FTL's precedence: exact allow, exact deny, regex allow, regex deny,
+// gravity allow and finally gravity block. Disabled entries are ignored, and
+// so are entries for other domains returned by a partial search. Group
+// assignments are not taken into account.
+func (r *DomainSearchResult) Blocked(domain string) bool {
+	matches := func(typ, kind string) bool {
+		for _, d := range r.Domains {
+			if d.Enabled && d.Type == typ && d.Kind == kind && (kind == "regex" || d.Domain == domain) {
+				return true
+			}
+		}
+		return false
+	}
+	listed := func(typ string) bool {
+		for _, g := range r.Gravity {
+			if g.Enabled && g.Type == typ && g.Domain == domain {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case matches("allow", "exact"):
+		return false
+	case matches("deny", "exact"):
+		return true
+	case matches("allow", "regex"):
+		return false
+	case matches("deny", "regex"):
+		return true
+	case listed("allow"):
+		return false
+	default:
+		return listed("block")
+	}
+}
+
+// DomainMatch is an exact or regex allow/deny entry matching a domain
+type DomainMatch struct {
+	ID      int
+	Domain  string
+	Type    string // "allow" or "deny"
+	Kind    string // "exact" or "regex"
+	Comment string
+	Enabled bool
+	Groups  []int
+}
+
+// GravityMatch is a domain on a subscribed allow or block list
+type GravityMatch struct {
+	ID      int
+	Domain  string
+	Address string // URL of the list
+	Type    string // "allow" or "block"
+	Comment string
+	Enabled bool
+	Groups  []int
+}
+
+// SystemInfo describes the resource usage of the host running Pi-hole
+type SystemInfo struct {
+	Uptime    time.Duration
+	Processes int
+
+	// Memory sizes are in KiB
+	MemoryTotal       int64
+	MemoryUsed        int64
+	MemoryAvailable   int64
+	MemoryPercentUsed float64
+	SwapTotal         int64
+	SwapUsed          int64
+	SwapPercentUsed   float64
+
+	CPUCores   int
+	CPUPercent float64
+
+	// Load is the 1, 5 and 15 minute load average
+	Load []float64
+}
+
+// HostInfo identifies the host running Pi-hole
+type HostInfo struct {
+	Hostname string
+	Sysname  string
+	Release  string
+	Version  string
+	Machine  string
+	Model    string
+}
+
+// FTLInfo describes the running pihole-FTL process
+type FTLInfo struct {
+	PID              int
+	Uptime           time.Duration
+	PrivacyLevel     int
+	MemoryPercent    float64
+	CPUPercent       float64
+	QueryFrequency   float64
+	ActiveClients    int
+	TotalClients     int
+	GravityDomains   int
+	AllowDestructive bool
+}
+
+// DatabaseInfo describes Pi-hole's long-term query database
+type DatabaseInfo struct {
+	// Size is the size of the database file in bytes
+	Size              int64
+	Queries           int
+	EarliestTimestamp int64
+	ModifiedAt        int64
+	SQLiteVersion     string
+}
+
+// SensorsInfo holds the temperature readings of the host
+type SensorsInfo struct {
+	CPUTemp  float64
+	HotLimit float64
+
+	// Unit is the temperature unit, "C", "F" or "K"
+	Unit string
+
+	Sensors []Sensor
+}
+
+// Sensor is a hardware monitoring device with its temperature readings
+type Sensor struct {
+	Name  string
+	Path  string
+	Temps []SensorTemp
+}
+
+// SensorTemp is a single temperature reading of a sensor
+type SensorTemp struct {
+	Name  string
+	Value float64
+	Max   float64
+	Crit  float64
+}
+
+// VersionInfo holds the versions of the Pi-hole components
+type VersionInfo struct {
+	Core ComponentVersion
+	Web  ComponentVersion
+	FTL  ComponentVersion
+
+	// Docker is the image tag when running in Docker, otherwise empty
+	Docker string
+}
+
+// ComponentVersion is the installed and latest available version of a Pi-hole component
+type ComponentVersion struct {
+	Version       string
+	Branch        string
+	Hash          string
+	RemoteVersion string
+}
+
+// Session is an API session. Pi-hole only allows a limited number at a time
+// (webserver.api.max_sessions), and a session ends when it is logged out or
+// has been idle for webserver.session.timeout.
+type Session struct {
+	// ID identifies the session in the sessions API. It is not the session ID
+	// used for authentication.
+	ID int
+
+	// Current is true for the session the request was made with
+	Current bool
+
+	Valid      bool
+	LoginAt    int64
+	LastActive int64
+	ValidUntil int64
+	RemoteAddr string
+	UserAgent  string
+}
+
 // Config contains the configuration for creating a Pi-hole client
 type Config struct {
-	// BaseURL is the Pi-hole server URL (e.g., "http://pi.hole")
+	// BaseURL is the Pi-hole server URL (e.g., "http://pi.hole"). It may
+	// include a path prefix under which a reverse proxy serves the API, such
+	// as "https://proxy.example.com/pihole".
 	BaseURL string
 
 	// Password is the admin password for authentication
@@ -37,11 +375,107 @@ type Config struct {
 	// CAFile is an optional path to a CA certificate for TLS
 	CAFile string
 
+	// CAPEM holds PEM encoded CA certificates, like CAFile but inline. The
+	// certificates of both are trusted if both are set.
+	CAPEM string
+
+	// The client certificate and key for mutual TLS, each either as a path
+	// to a PEM file or inline PEM
+	ClientCertFile string
+	ClientCertPEM  string
+	ClientKeyFile  string
+	ClientKeyPEM   string
+
+	// TLSServerName overrides the host name the server certificate is
+	// verified against, e.g. when connecting by IP address
+	TLSServerName string
+
 	// InsecureSkipVerify disables TLS certificate verification.
 	// WARNING: This is insecure and should only be used for testing
 	// or in environments where you trust the network.
 	InsecureSkipVerify bool
 
-	// SessionID can be provided to reuse an existing session
+	// SessionID can be provided to reuse an existing session. The client
+	// neither logs in again when it is rejected nor reaps sessions with it.
 	SessionID string
+
+	// Headers are sent with every request, such as the credentials of an
+	// authenticating reverse proxy in front of Pi-hole
+	Headers map[string]string
+
+	// BasicAuthUsername and BasicAuthPassword are sent as HTTP basic
+	// authentication with every request when either is set. Pi-hole itself
+	// ignores them; they are meant for a reverse proxy.
+	BasicAuthUsername string
+	BasicAuthPassword string
+
+	// ProxyURL routes requests through an HTTP, HTTPS or SOCKS5 proxy. If
+	// empty, the proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables.
+	ProxyURL string
+
+	// RequestTimeout bounds each HTTP request attempt, including reading the
+	// response body. Zero means no timeout.
+	RequestTimeout time.Duration
+
+	// Retry controls how failed requests are retried.
+	// If nil, DefaultRetryPolicy is used.
+	Retry *RetryPolicy
+
+	// ConsistencyTimeout bounds how long a DNS or CNAME write waits for the
+	// change to show up when the records are read back. Zero disables the
+	// check.
+	ConsistencyTimeout time.Duration
+
+	// ReapStaleSessions revokes the sessions left behind by earlier runs that
+	// crashed before logging out: sessions other than the current one whose
+	// user agent matches ReapUserAgent and that have been idle for
+	// StaleSessionAge. Orphaned sessions otherwise hold API seats until they
+	// time out. Sessions are reaped once logged in, or, when the login is
+	// rejected for lack of seats, with a session borrowed from SessionJournal
+	// before logging in again.
+	ReapStaleSessions bool
+
+	// StaleSessionAge is how long a session must have been idle before
+	// ReapStaleSessions revokes it. If zero, DefaultStaleSessionAge is used.
+	StaleSessionAge time.Duration
+
+	// ReapUserAgent is the product token, such as "terraform-provider-pihole/",
+	// that the user agent of a session must contain to be reaped, so that the
+	// sessions of other versions of the same program are reaped as well. If
+	// empty, the user agent must equal UserAgent.
+	ReapUserAgent string
+
+	// SessionJournal is a directory in which the sessions the client logs in
+	// for are recorded until it logs out, when ReapStaleSessions is set. The
+	// sessions of crashed runs stay in it, and a login rejected for lack of
+	// seats borrows one of them to reap with. If empty, such a login fails.
+	SessionJournal string
+}
+
+// DefaultStaleSessionAge is just under Pi-hole's default
+// webserver.session.timeout of 30 minutes, so that a run which has been
+// waiting on something else for a while keeps its session
+const DefaultStaleSessionAge = 25 * time.Minute
+
+// RetryPolicy controls how failed requests are retried, both for transport
+// errors and server errors and for transient Pi-hole API errors such as
+// "item already present" during ForceNew operations.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a failed request is retried
+	MaxRetries int
+
+	// WaitMin is the backoff before the first retry. It doubles on each
+	// subsequent retry.
+	WaitMin time.Duration
+
+	// WaitMax caps the backoff between retries
+	WaitMax time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used when none is configured
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 4,
+	WaitMin:    200 * time.Millisecond,
+	WaitMax:    30 * time.Second,
 }
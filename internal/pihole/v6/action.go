@@ -0,0 +1,75 @@
+package v6
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const actionPath = "/api/action"
+
+type actionService struct {
+	client *Client
+}
+
+// RestartDNS restarts FTL's resolver and waits until FTL answers API requests
+// again
+func (s *actionService) RestartDNS(ctx context.Context) error {
+	if err := s.run(ctx, "restartdns"); err != nil {
+		return err
+	}
+
+	return s.waitRestart(ctx)
+}
+
+// FlushLogs empties the query log
+func (s *actionService) FlushLogs(ctx context.Context) error {
+	return s.run(ctx, "flush/logs")
+}
+
+// FlushARP empties the ARP cache
+func (s *actionService) FlushARP(ctx context.Context) error {
+	defer s.client.network.cache.invalidate()
+
+	return s.run(ctx, "flush/arp")
+}
+
+// FlushNetwork empties the network table
+func (s *actionService) FlushNetwork(ctx context.Context) error {
+	defer s.client.network.cache.invalidate()
+
+	return s.run(ctx, "flush/network")
+}
+
+// run triggers an action
+func (s *actionService) run(ctx context.Context, action string) error {
+	resp, err := s.client.post(ctx, actionPath+"/"+action, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// waitRestart polls FTL until it answers after the restart was triggered.
+// Depending on the version FTL restarts under a new PID or reloads the
+// resolver in-process, keeping its PID and uptime, so neither tells whether
+// the restart is over. Either way the action only responds once the restart
+// has begun, and the API is down until it is done. Errors while polling are
+// expected for that reason, so only the context ends the wait.
+func (s *actionService) waitRestart(ctx context.Context) error {
+	for poll := 1; ; poll++ {
+		if err := sleep(ctx, pollInterval(poll)); err != nil {
+			return fmt.Errorf("FTL did not respond after restarting DNS: %w", err)
+		}
+
+		if _, err := s.client.info.FTL(ctx); err == nil {
+			return nil
+		}
+	}
+}
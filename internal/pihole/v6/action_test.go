@@ -0,0 +1,125 @@
+package v6
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestRestartDNSWaitsForFTL(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c := newFakeClient(t, srv)
+	srv.SetRestartDowntime(300 * time.Millisecond)
+
+	start := time.Now()
+	if err := c.Actions().RestartDNS(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if srv.Restarts() != 1 {
+		t.Errorf("expected 1 restart, got %d", srv.Restarts())
+	}
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("returned after %s, before FTL was back", elapsed)
+	}
+}
+
+func TestRestartDNSInProcess(t *testing.T) {
+	for _, downtime := range []time.Duration{0, 300 * time.Millisecond} {
+		t.Run(downtime.String(), func(t *testing.T) {
+			srv := fake.NewServer()
+			defer srv.Close()
+
+			c := newFakeClient(t, srv)
+			srv.SetRestartInProcess(true)
+			srv.SetRestartDowntime(downtime)
+
+			// FTL keeps its PID and uptime, so only answering again ends the wait
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			start := time.Now()
+			if err := c.Actions().RestartDNS(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if srv.Restarts() != 1 {
+				t.Errorf("expected 1 restart, got %d", srv.Restarts())
+			}
+			if elapsed := time.Since(start); elapsed < downtime {
+				t.Errorf("returned after %s, before FTL was back", elapsed)
+			}
+		})
+	}
+}
+
+func TestRestartDNSTimeout(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c := newFakeClient(t, srv)
+	srv.SetRestartDowntime(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if err := c.Actions().RestartDNS(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to time out, got %v", err)
+	}
+}
+
+func TestFlushActions(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.AddQuery(fake.Query{Time: 1700000000, Type: "A", Domain: "example.com", Status: "FORWARDED", ClientIP: "192.168.1.10"})
+	srv.AddDevice(fake.Device{HWAddr: "aa:bb:cc:dd:ee:01", Addresses: []fake.Address{{IP: "192.168.1.10"}}})
+
+	c := newFakeClient(t, srv)
+	ctx := context.Background()
+
+	if err := c.Actions().FlushLogs(ctx); err != nil {
+		t.Fatal(err)
+	}
+	queries := 0
+	if err := c.Queries().Each(ctx, pihole.QueryFilter{}, func(pihole.QueryLogEntry) bool { queries++; return true }); err != nil {
+		t.Fatal(err)
+	}
+	if queries != 0 {
+		t.Errorf("expected an empty query log, got %d queries", queries)
+	}
+
+	if err := c.Actions().FlushARP(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if devices := srv.Devices(); len(devices) != 1 || len(devices[0].Addresses) != 0 {
+		t.Errorf("expected the device without addresses, got %+v", devices)
+	}
+
+	if err := c.Actions().FlushNetwork(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if devices := srv.Devices(); len(devices) != 0 {
+		t.Errorf("expected an empty network table, got %+v", devices)
+	}
+}
+
+func TestActionsDisabled(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.SetInfo(fake.Info{AllowDestructive: false})
+	c := newFakeClient(t, srv)
+
+	var apiErr *pihole.APIError
+	if err := c.Actions().FlushLogs(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403, got %v", err)
+	}
+}
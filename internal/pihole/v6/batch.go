@@ -0,0 +1,417 @@
+package v6
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+const (
+	configPath = "/api/config"
+
+	// batchWindow is how long the first pending write waits for others to
+	// join it before the batch is applied.
+	batchWindow = 50 * time.Millisecond
+)
+
+// writeOp is a single pending create or delete of a dns.hosts or
+// dns.cnameRecords entry.
+type writeOp struct {
+	// add is true for creates and false for deletes
+	add bool
+
+	// domain is the record domain the operation applies to
+	domain string
+
+	// entry is the raw config entry to add. Deletes resolve it at apply time.
+	entry string
+
+	// force is passed through to the single-write fallback for creates
+	force bool
+
+	ctx  context.Context
+	done chan error
+
+	// err is the result of the operation once submit has returned
+	err error
+}
+
+// writeBatcher coalesces creates and deletes against one config array into a
+// single PATCH of /api/config. Every write to dns.hosts makes FTL rewrite its
+// configuration and reload dnsmasq, so applying a batch at once is far cheaper
+// than one PUT or DELETE per record.
+//
+// Operations are applied in submission order against a fresh copy of the
+// array. If an operation cannot be applied in the batch, or the PATCH itself
+// fails, the affected operations fall back to the per-entry endpoints so each
+// caller gets the error for its own record.
+//
+// An operation only completes once its change is visible when the array is
+// read back, so callers never observe a write that has not taken effect yet.
+//
+// Pi-hole cannot make the PATCH conditional on the array it was computed
+// from, so the last writer wins. After the PATCH the array is read back: if
+// the batch's own changes or the entries it left alone went missing, another
+// writer replaced the array at the same time and the batch is replayed one
+// entry at a time, which only touches its own records. Entries that another
+// writer added between the fetch and the PATCH are still lost unless that
+// writer checks for them, as the provider does.
+type writeBatcher struct {
+	client *Client
+
+	// key is the name of the array under config.dns (e.g. "hosts")
+	key string
+
+	// fetch returns the current raw entries of the array
+	fetch func(ctx context.Context) ([]string, error)
+
+	// domainsOf returns the record domains of a raw entry, which holds one
+	// record for each of them
+	domainsOf func(entry string) []string
+
+	// without returns entry with the record of domain removed, or "" if it
+	// holds no other record
+	without func(entry, domain string) string
+
+	// conflicts reports whether entry cannot be added next to existing
+	conflicts func(existing []string, entry string) bool
+
+	// put and del write a single entry through the per-entry endpoints
+	put func(ctx context.Context, entry string, force bool) error
+	del func(ctx context.Context, entry string) error
+
+	// flushed is called after every batch, whatever its outcome
+	flushed func()
+
+	mu      sync.Mutex
+	pending []*writeOp
+	timer   *time.Timer
+
+	// flushMu ensures only one batch is applied at a time
+	flushMu sync.Mutex
+}
+
+// submit queues ops to be applied in order in the next batch and waits for
+// their results. The first error encountered is returned.
+func (b *writeBatcher) submit(ctx context.Context, ops ...*writeOp) error {
+	for _, op := range ops {
+		op.ctx = ctx
+		op.done = make(chan error, 1)
+	}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, ops...)
+	if b.timer == nil {
+		b.timer = time.AfterFunc(batchWindow, b.flush)
+	}
+	b.mu.Unlock()
+
+	var firstErr error
+	for _, op := range ops {
+		select {
+		case op.err = <-op.done:
+			if op.err != nil && firstErr == nil {
+				firstErr = op.err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return firstErr
+}
+
+// flush applies all pending operations
+func (b *writeBatcher) flush() {
+	b.mu.Lock()
+	ops := b.pending
+	b.pending = nil
+	b.timer = nil
+	b.mu.Unlock()
+
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+	defer b.flushed()
+
+	live := make([]*writeOp, 0, len(ops))
+	for _, op := range ops {
+		if err := op.ctx.Err(); err != nil {
+			op.done <- err
+			continue
+		}
+		live = append(live, op)
+	}
+
+	if len(live) == 0 {
+		return
+	}
+
+	// Callers may give up while the batch is in flight, which must not abort
+	// the writes of the other operations sharing it.
+	ctx := context.WithoutCancel(live[0].ctx)
+
+	current, err := b.fetch(ctx)
+	if err != nil {
+		for _, op := range live {
+			op.done <- err
+		}
+		return
+	}
+
+	entries := slices.Clone(current)
+	var applied, fallback []*writeOp
+	deferred := make(map[string]bool)
+
+	for _, op := range live {
+		// Once an operation on a domain falls back, later operations on the
+		// same domain must follow it to keep their relative order.
+		if deferred[op.domain] {
+			fallback = append(fallback, op)
+			continue
+		}
+
+		if op.add {
+			if b.conflicts(entries, op.entry) {
+				deferred[op.domain] = true
+				fallback = append(fallback, op)
+				continue
+			}
+			entries = append(entries, op.entry)
+		} else {
+			entries = b.remove(entries, op.domain)
+		}
+
+		applied = append(applied, op)
+	}
+
+	var written map[*writeOp]bool
+	if len(applied) > 0 && !slices.Equal(entries, current) {
+		if err = b.patch(ctx, entries); err != nil {
+			// Replay everything one entry at a time to find out which
+			// operations are actually at fault.
+			fallback = live
+			applied = nil
+		} else if err = b.converge(ctx, applied); b.overwritten(ctx, err, current, entries) {
+			// Another writer replaced the array at the same time. Replaying
+			// one entry at a time writes the records that were lost without
+			// touching those of others.
+			written = make(map[*writeOp]bool, len(applied))
+			for _, op := range applied {
+				written[op] = true
+			}
+			fallback = live
+			applied = nil
+		}
+	} else {
+		err = b.converge(ctx, applied)
+	}
+
+	for _, op := range applied {
+		op.done <- err
+	}
+
+	if len(fallback) > 0 {
+		b.replay(ctx, current, fallback, written)
+	}
+}
+
+// overwritten reports whether another writer replaced the array at the same
+// time as the batch, which was computed from current and written as entries,
+// given the result of waiting for the batch to become visible. Either entries
+// are missing from the array although the batch became visible, or it never
+// did while the array changed all the same, so that it cannot just be slow
+// to apply. If the array cannot be read it is assumed not to be.
+func (b *writeBatcher) overwritten(ctx context.Context, convergeErr error, current, entries []string) bool {
+	if convergeErr != nil && !errors.Is(convergeErr, pihole.ErrNotConsistent) {
+		return false
+	}
+
+	latest, err := b.fetch(ctx)
+	if err != nil {
+		return false
+	}
+
+	if convergeErr != nil {
+		return !slices.Equal(latest, current)
+	}
+	for _, entry := range entries {
+		if !slices.Contains(latest, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// replay applies ops one at a time through the per-entry endpoints. Adds in
+// written were already part of a PATCH, so their entries being present is
+// their own doing rather than a conflict.
+func (b *writeBatcher) replay(ctx context.Context, current []string, ops []*writeOp, written map[*writeOp]bool) {
+	// The batch may have been partly applied, so start from the live array
+	if latest, err := b.fetch(ctx); err == nil {
+		current = latest
+	}
+
+	for _, op := range ops {
+		if err := op.ctx.Err(); err != nil {
+			op.done <- err
+			continue
+		}
+
+		if op.add {
+			var err error
+			if !written[op] || !slices.Contains(current, op.entry) {
+				err = b.add(op)
+			}
+			if written[op] && errors.Is(err, pihole.ErrAlreadyExists) {
+				err = nil
+			}
+			if err == nil {
+				current = append(current, op.entry)
+				err = b.converge(op.ctx, []*writeOp{op})
+			}
+			op.done <- err
+			continue
+		}
+
+		// Entries without the record are already gone, as delete is idempotent
+		var err error
+		for err == nil {
+			idx := slices.IndexFunc(current, func(e string) bool { return b.holds(e, op.domain) })
+			if idx < 0 {
+				err = b.converge(op.ctx, []*writeOp{op})
+				break
+			}
+
+			// The other records of the entry are written back without it
+			entry, rest := current[idx], b.without(current[idx], op.domain)
+			if err = b.del(op.ctx, entry); err == nil && rest != "" {
+				err = b.put(op.ctx, rest, false)
+			}
+			current = b.remove(current, op.domain)
+		}
+		op.done <- err
+	}
+}
+
+// holds reports whether entry holds a record for domain
+func (b *writeBatcher) holds(entry, domain string) bool {
+	return slices.Contains(b.domainsOf(entry), domain)
+}
+
+// remove returns entries without the records of domain, keeping the other
+// records of entries that hold several. entries is modified.
+func (b *writeBatcher) remove(entries []string, domain string) []string {
+	kept := entries[:0]
+	for _, entry := range entries {
+		if b.holds(entry, domain) {
+			entry = b.without(entry, domain)
+		}
+		if entry != "" {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// add writes the entry of op through the per-entry endpoint. Pi-hole may
+// still report an entry as present while it applies a prior delete, so the
+// write is retried as long as the array itself shows no conflicting entry.
+func (b *writeBatcher) add(op *writeOp) error {
+	err := b.put(op.ctx, op.entry, op.force)
+
+	for retry := 1; retry <= b.client.retry.MaxRetries && errors.Is(err, pihole.ErrAlreadyExists); retry++ {
+		entries, fetchErr := b.fetch(op.ctx)
+		if fetchErr != nil || b.conflicts(entries, op.entry) {
+			// The entry really is present
+			return err
+		}
+
+		if waitErr := b.client.waitRetry(op.ctx, retry); waitErr != nil {
+			return waitErr
+		}
+
+		err = b.put(op.ctx, op.entry, op.force)
+	}
+
+	return err
+}
+
+// converge waits until the changes made by ops are visible in the array. For
+// each domain only the last operation counts, as it determines the final
+// state.
+func (b *writeBatcher) converge(ctx context.Context, ops []*writeOp) error {
+	timeout := b.client.consistencyTimeout
+	if timeout <= 0 || len(ops) == 0 {
+		return nil
+	}
+
+	final := make(map[string]*writeOp, len(ops))
+	for _, op := range ops {
+		final[op.domain] = op
+	}
+
+	visible := func(entries []string) bool {
+		for domain, op := range final {
+			if op.add {
+				if !slices.Contains(entries, op.entry) {
+					return false
+				}
+			} else if slices.ContainsFunc(entries, func(e string) bool { return b.holds(e, domain) }) {
+				return false
+			}
+		}
+		return true
+	}
+
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for poll := 1; ; poll++ {
+		entries, err := b.fetch(pollCtx)
+		if err == nil && visible(entries) {
+			return nil
+		}
+		if err != nil && pollCtx.Err() == nil {
+			return err
+		}
+
+		if err := sleep(pollCtx, pollInterval(poll)); err != nil {
+			break
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: dns.%s not updated after %s", pihole.ErrNotConsistent, b.key, timeout)
+}
+
+// patch replaces the whole array with entries
+func (b *writeBatcher) patch(ctx context.Context, entries []string) error {
+	body := map[string]interface{}{
+		"config": map[string]interface{}{
+			"dns": map[string]interface{}{
+				b.key: entries,
+			},
+		},
+	}
+
+	resp, err := b.client.patch(ctx, configPath, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
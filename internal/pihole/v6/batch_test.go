@@ -0,0 +1,308 @@
+package v6
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+// hostsServer is a minimal dns.hosts endpoint for exercising the write batcher
+type hostsServer struct {
+	mu        sync.Mutex
+	hosts     []string
+	patches   int
+	puts      int
+	failPatch bool
+	reject    string
+
+	// race replaces the hosts once right after the next PATCH, given the
+	// hosts before it, as another writer's PATCH landing at the same time
+	race func(before []string) []string
+}
+
+func (h *hostsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == dnsHostsPath:
+		var resp dnsListResponse
+		resp.Config.DNS.Hosts = h.hosts
+		_ = json.NewEncoder(w).Encode(resp)
+	case r.Method == http.MethodPatch && r.URL.Path == configPath:
+		h.patches++
+		if h.failPatch {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var body struct {
+			Config struct {
+				DNS struct {
+					Hosts []string `json:"hosts"`
+				} `json:"dns"`
+			} `json:"config"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		before := h.hosts
+		h.hosts = body.Config.DNS.Hosts
+		if h.race != nil {
+			h.hosts = h.race(before)
+			h.race = nil
+		}
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, dnsHostsPath+"/"):
+		h.puts++
+		entry, _ := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), dnsHostsPath+"/"))
+		if entry == h.reject {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h.hosts = append(h.hosts, entry)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, dnsHostsPath+"/"):
+		entry, _ := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), dnsHostsPath+"/"))
+		h.hosts = slices.DeleteFunc(h.hosts, func(e string) bool { return e == entry })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, h http.Handler) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(context.Background(), pihole.Config{BaseURL: srv.URL, SessionID: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestBatchedCreatesUseSinglePatch(t *testing.T) {
+	h := &hostsServer{hosts: []string{"10.0.0.1 existing.lan"}}
+	c := newTestClient(t, h)
+
+	const count = 20
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := c.LocalDNS().Create(context.Background(), fmt.Sprintf("host-%d.lan", i), "10.0.1.1", nil); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// All creates are submitted well within one batch window
+	if h.patches > 2 {
+		t.Errorf("expected creates to be coalesced, got %d config updates", h.patches)
+	}
+	if len(h.hosts) != count+1 {
+		t.Errorf("expected %d hosts, got %d", count+1, len(h.hosts))
+	}
+
+	records, err := c.LocalDNS().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != count+1 {
+		t.Errorf("expected %d records after invalidation, got %d", count+1, len(records))
+	}
+}
+
+func TestBatchedForceReplacesRecord(t *testing.T) {
+	h := &hostsServer{hosts: []string{"10.0.0.1 replace.lan"}}
+	c := newTestClient(t, h)
+
+	if _, err := c.LocalDNS().Create(context.Background(), "replace.lan", "10.0.0.2", &pihole.CreateOptions{Force: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(h.hosts, []string{"10.0.0.2 replace.lan"}) {
+		t.Errorf("unexpected hosts after forced create: %v", h.hosts)
+	}
+}
+
+func TestDeleteFromMultiNameLine(t *testing.T) {
+	for _, failPatch := range []bool{false, true} {
+		t.Run(fmt.Sprintf("failPatch=%t", failPatch), func(t *testing.T) {
+			h := &hostsServer{hosts: []string{"10.0.0.1 a.lan b.lan c.lan"}, failPatch: failPatch}
+			c := newTestClient(t, h).LocalDNS()
+			ctx := context.Background()
+
+			if err := c.Delete(ctx, "b.lan"); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(h.hosts, []string{"10.0.0.1 a.lan c.lan"}) {
+				t.Errorf("expected only b.lan to be removed from the line, got %v", h.hosts)
+			}
+			if _, err := c.Get(ctx, "b.lan"); !errors.Is(err, pihole.ErrDNSNotFound) {
+				t.Errorf("expected ErrDNSNotFound, got %v", err)
+			}
+			if _, err := c.Get(ctx, "a.lan"); err != nil {
+				t.Errorf("expected a.lan to remain, got %v", err)
+			}
+
+			for _, domain := range []string{"a.lan", "c.lan"} {
+				if err := c.Delete(ctx, domain); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(h.hosts) != 0 {
+				t.Errorf("expected the line to be removed with its last name, got %v", h.hosts)
+			}
+		})
+	}
+}
+
+func TestDeleteFromMultiDomainCNAME(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.SetCNAMEs("a.lan,b.lan,target.lan,300")
+
+	c := newFakeClient(t, srv).LocalCNAME()
+	ctx := context.Background()
+
+	if err := c.Delete(ctx, "a.lan"); err != nil {
+		t.Fatal(err)
+	}
+	if cnames := srv.CNAMEs(); !slices.Equal(cnames, []string{"b.lan,target.lan,300"}) {
+		t.Errorf("expected only a.lan to be removed from the entry, got %v", cnames)
+	}
+	if _, err := c.Get(ctx, "a.lan"); !errors.Is(err, pihole.ErrCNAMENotFound) {
+		t.Errorf("expected ErrCNAMENotFound, got %v", err)
+	}
+
+	if err := c.Delete(ctx, "b.lan"); err != nil {
+		t.Fatal(err)
+	}
+	if cnames := srv.CNAMEs(); len(cnames) != 0 {
+		t.Errorf("expected the entry to be removed with its last domain, got %v", cnames)
+	}
+}
+
+func TestBatchOverwrittenByConcurrentPatch(t *testing.T) {
+	// Another writer adds a record from the array as it was before the batch
+	h := &hostsServer{
+		hosts: []string{"10.0.0.1 existing.lan", "10.0.0.2 gone.lan"},
+		race: func(before []string) []string {
+			return append(slices.Clone(before), "10.0.0.3 other.lan")
+		},
+	}
+	c := newTestClient(t, h)
+	ctx := context.Background()
+
+	errs := make(chan error, 2)
+	go func() {
+		_, err := c.LocalDNS().Create(ctx, "new.lan", "10.0.1.1", nil)
+		errs <- err
+	}()
+	go func() { errs <- c.LocalDNS().Delete(ctx, "gone.lan") }()
+	for range 2 {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The batch is replayed one record at a time on top of the other write
+	expected := []string{"10.0.0.1 existing.lan", "10.0.0.3 other.lan", "10.0.1.1 new.lan"}
+	hosts := slices.Clone(h.hosts)
+	slices.Sort(hosts)
+	if !slices.Equal(hosts, expected) {
+		t.Errorf("expected hosts %v, got %v", expected, hosts)
+	}
+	if h.puts != 1 {
+		t.Errorf("expected the create to be replayed, got %d single writes", h.puts)
+	}
+}
+
+func TestBatchFallsBackToSingleWrites(t *testing.T) {
+	h := &hostsServer{failPatch: true, reject: "10.0.1.1 bad.lan"}
+	c := newTestClient(t, h)
+
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, domain := range []string{"good-1.lan", "bad.lan", "good-2.lan"} {
+		wg.Add(1)
+		go func(domain string) {
+			defer wg.Done()
+			_, err := c.LocalDNS().Create(context.Background(), domain, "10.0.1.1", nil)
+			mu.Lock()
+			errs[domain] = err
+			mu.Unlock()
+		}(domain)
+	}
+	wg.Wait()
+
+	if errs["bad.lan"] == nil {
+		t.Error("expected an error for the rejected record")
+	}
+	for _, domain := range []string{"good-1.lan", "good-2.lan"} {
+		if errs[domain] != nil {
+			t.Errorf("unexpected error for %s: %v", domain, errs[domain])
+		}
+	}
+	if h.puts != 3 {
+		t.Errorf("expected 3 single writes, got %d", h.puts)
+	}
+}
+
+func TestWritesWaitForVisibility(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c := newFakeClient(t, srv)
+	srv.DelayVisibility(3)
+	ctx := context.Background()
+
+	if _, err := c.LocalDNS().Create(ctx, "visible.lan", "10.0.0.1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.LocalDNS().Get(ctx, "visible.lan"); err != nil {
+		t.Fatalf("created record not visible: %v", err)
+	}
+
+	if err := c.LocalDNS().Delete(ctx, "visible.lan"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.LocalDNS().Get(ctx, "visible.lan"); !errors.Is(err, pihole.ErrDNSNotFound) {
+		t.Fatalf("deleted record still visible: %v", err)
+	}
+}
+
+func TestWritesConsistencyTimeout(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c, err := NewClient(context.Background(), pihole.Config{
+		BaseURL:            srv.URL,
+		Password:           fake.DefaultPassword,
+		ConsistencyTimeout: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.DelayVisibility(1000)
+
+	_, err = c.LocalCNAME().Create(context.Background(), "slow.lan", "target.lan", nil)
+	if !errors.Is(err, pihole.ErrNotConsistent) {
+		t.Fatalf("expected ErrNotConsistent, got %v", err)
+	}
+}
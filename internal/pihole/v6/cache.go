@@ -0,0 +1,87 @@
+package v6
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// listCache holds a snapshot of a list endpoint (dns.hosts, dns.cnameRecords,
+// clients, the network table) for the lifetime of a client, which is a single
+// Terraform run.
+// Concurrent loads are de-duplicated so that refreshing N resources costs one
+// request instead of N, and any write invalidates the snapshot.
+type listCache[T any] struct {
+	group singleflight.Group
+
+	mu    sync.Mutex
+	gen   uint64
+	items []T
+	valid bool
+}
+
+// get returns the cached snapshot, loading it with fetch if it is not present.
+// The returned slice is a copy and may be modified by the caller.
+func (c *listCache[T]) get(ctx context.Context, fetch func(context.Context) ([]T, error)) ([]T, error) {
+	c.mu.Lock()
+	if c.valid {
+		items := slices.Clone(c.items)
+		c.mu.Unlock()
+		return items, nil
+	}
+	gen := c.gen
+	c.mu.Unlock()
+
+	// Key the flight by generation so a load that started before an
+	// invalidation is never shared with callers that arrive after it.
+	flight := c.group.DoChan(strconv.FormatUint(gen, 10), func() (interface{}, error) {
+		// A flight for this generation may have completed between the check
+		// above and joining the group
+		c.mu.Lock()
+		if c.valid && c.gen == gen {
+			items := c.items
+			c.mu.Unlock()
+			return items, nil
+		}
+		c.mu.Unlock()
+
+		// The load is shared, so it must not fail for every caller when the
+		// one that started it is cancelled. It keeps the caller's values for
+		// logging and is still bounded by the client's request timeout.
+		items, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		if c.gen == gen {
+			c.items = items
+			c.valid = true
+		}
+		c.mu.Unlock()
+
+		return items, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-flight:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return slices.Clone(res.Val.([]T)), nil
+	}
+}
+
+// invalidate drops the snapshot so the next get reloads it from the API.
+func (c *listCache[T]) invalidate() {
+	c.mu.Lock()
+	c.gen++
+	c.items = nil
+	c.valid = false
+	c.mu.Unlock()
+}
@@ -0,0 +1,125 @@
+package v6
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestListCacheDeduplicatesLoads(t *testing.T) {
+	var c listCache[string]
+	var calls int32
+	release := make(chan struct{})
+
+	fetch := func(context.Context) ([]string, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []string{"a", "b"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := c.get(context.Background(), fetch)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(items) != 2 {
+				t.Errorf("expected 2 items, got %d", len(items))
+			}
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+
+	if _, err := c.get(context.Background(), fetch); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("expected a single fetch, got %d", n)
+	}
+}
+
+func TestListCacheInvalidate(t *testing.T) {
+	var c listCache[string]
+	var calls int32
+
+	fetch := func(context.Context) ([]string, error) {
+		atomic.AddInt32(&calls, 1)
+		return []string{"a"}, nil
+	}
+
+	items, err := c.get(context.Background(), fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Callers own the returned slice
+	items[0] = "changed"
+
+	items, err = c.get(context.Background(), fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0] != "a" {
+		t.Fatalf("cached snapshot was modified through a returned slice: %q", items[0])
+	}
+
+	c.invalidate()
+
+	if _, err := c.get(context.Background(), fetch); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("expected 2 fetches after invalidation, got %d", n)
+	}
+}
+
+func TestListCacheCancelledCaller(t *testing.T) {
+	var c listCache[string]
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	fetch := func(ctx context.Context) ([]string, error) {
+		close(started)
+		select {
+		case <-release:
+			return []string{"a"}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// The caller that starts the load gives up while it is in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.get(ctx, fetch)
+		first <- err
+	}()
+	<-started
+
+	waiter := make(chan error, 1)
+	go func() {
+		_, err := c.get(context.Background(), fetch)
+		waiter <- err
+	}()
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancelled caller to return its own error, got %v", err)
+	}
+
+	close(release)
+	if err := <-waiter; err != nil {
+		t.Fatalf("expected the waiter to get the shared load, got %v", err)
+	}
+}
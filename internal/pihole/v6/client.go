@@ -3,16 +3,19 @@ package v6
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"net/url"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/hashicorp/go-cleanhttp"
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
@@ -26,70 +29,153 @@ type Client struct {
 	password  string
 	userAgent string
 	http      *http.Client
+	retry     pihole.RetryPolicy
+
+	// headers and basic auth are sent with every request for a reverse proxy
+	headers           map[string]string
+	basicAuthUsername string
+	basicAuthPassword string
+
+	// consistencyTimeout bounds read-after-write checks of DNS and CNAME writes
+	consistencyTimeout time.Duration
 
 	sessionID   string
 	sessionLock sync.RWMutex
 
+	// externalSession is set when the session was passed in rather than
+	// created by logging in. Such a session is never replaced.
+	externalSession bool
+
+	// authLock serializes logging in again after the session was rejected
+	authLock sync.Mutex
+
+	// staleSessionAge is how long sessions are idle before they are reaped,
+	// reapUserAgent is the product token of the sessions that are, journal
+	// records the client's sessions for later runs if reaping is enabled, and
+	// revoked holds the stale sessions reaped when logging in
+	staleSessionAge time.Duration
+	reapUserAgent   string
+	journal         *sessionJournal
+	revoked         []pihole.Session
+
 	dns        *dnsService
 	cname      *cnameService
 	clientMgmt *clientService
+	network    *networkService
+	dhcp       *dhcpService
+	stats      *statsService
+	queries    *queryService
+	search     *searchService
+	info       *infoService
+	actions    *actionService
+	sessions   *sessionService
 }
 
 // NewClient creates a new Pi-hole v6 API client
 func NewClient(ctx context.Context, cfg pihole.Config) (*Client, error) {
-	httpClient := retryablehttp.NewClient()
-	httpClient.Logger = nil // Disable debug logging
-	stdClient := httpClient.StandardClient()
+	retry := pihole.DefaultRetryPolicy
+	if cfg.Retry != nil {
+		retry = *cfg.Retry
+	}
 
-	// Configure TLS settings
-	tlsConfig := &tls.Config{}
-	needsCustomTransport := false
+	staleSessionAge := pihole.DefaultStaleSessionAge
+	if cfg.StaleSessionAge > 0 {
+		staleSessionAge = cfg.StaleSessionAge
+	}
 
-	// Handle custom CA file
-	if cfg.CAFile != "" {
-		ca, err := os.ReadFile(cfg.CAFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read CA file %q: %w", cfg.CAFile, err)
-		}
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid Pi-hole URL %q: expected a URL such as http://pi.hole", cfg.BaseURL)
+	}
 
-		rootCAs := x509.NewCertPool()
-		if !rootCAs.AppendCertsFromPEM(ca) {
-			return nil, fmt.Errorf("failed to parse CA certificates from %q", cfg.CAFile)
+	proxy := http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
 		}
-
-		tlsConfig.RootCAs = rootCAs
-		needsCustomTransport = true
+		proxy = http.ProxyURL(proxyURL)
 	}
 
-	// Handle insecure skip verify (for self-signed certs without CA file)
-	if cfg.InsecureSkipVerify {
-		tlsConfig.InsecureSkipVerify = true
-		needsCustomTransport = true
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	if needsCustomTransport {
-		stdClient.Transport = &http.Transport{
-			TLSClientConfig: tlsConfig,
-		}
+	// TLS settings are applied to the pooled transport so that requests keep
+	// going through the proxy, keep-alives, logging and retries
+	transport := cleanhttp.DefaultPooledTransport()
+	transport.Proxy = proxy
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
 	}
 
+	httpClient := retryablehttp.NewClient()
+	httpClient.Logger = nil // Requests are logged through tflog by loggingTransport instead
+	httpClient.HTTPClient.Transport = &loggingTransport{next: transport}
+	httpClient.HTTPClient.Timeout = cfg.RequestTimeout
+	httpClient.RetryMax = retry.MaxRetries
+	httpClient.RetryWaitMin = retry.WaitMin
+	httpClient.RetryWaitMax = retry.WaitMax
+	httpClient.CheckRetry = checkRetry
+	// DefaultBackoff honours Retry-After on 429 and 503 responses
+	httpClient.Backoff = retryablehttp.DefaultBackoff
+	stdClient := httpClient.StandardClient()
+
 	c := &Client{
-		baseURL:   cfg.BaseURL,
+		// API paths start with a slash, so a path prefix must not end with one
+		baseURL:   strings.TrimRight(baseURL.String(), "/"),
 		password:  cfg.Password,
 		userAgent: cfg.UserAgent,
 		http:      stdClient,
+		retry:     retry,
 		sessionID: cfg.SessionID,
+
+		externalSession: cfg.SessionID != "",
+		staleSessionAge: staleSessionAge,
+		reapUserAgent:   cfg.ReapUserAgent,
+
+		headers:           cfg.Headers,
+		basicAuthUsername: cfg.BasicAuthUsername,
+		basicAuthPassword: cfg.BasicAuthPassword,
+
+		consistencyTimeout: cfg.ConsistencyTimeout,
 	}
 
-	c.dns = &dnsService{client: c}
-	c.cname = &cnameService{client: c}
+	c.dns = newDNSService(c)
+	c.cname = newCNAMEService(c)
 	c.clientMgmt = &clientService{client: c}
+	c.network = &networkService{client: c}
+	c.dhcp = &dhcpService{client: c}
+	c.stats = &statsService{client: c}
+	c.queries = &queryService{client: c}
+	c.search = &searchService{client: c}
+	c.info = &infoService{client: c}
+	c.actions = &actionService{client: c}
+	c.sessions = &sessionService{client: c}
+
+	if cfg.ReapStaleSessions && cfg.SessionJournal != "" {
+		c.journal = newSessionJournal(cfg.SessionJournal, c.baseURL)
+	}
 
-	// If no session ID provided, authenticate now
+	// If no session ID provided, authenticate now. Sessions are only reaped
+	// by a client that logs in itself, never with a session passed in.
 	if c.sessionID == "" {
-		if err := c.authenticate(ctx); err != nil {
+		err := c.authenticate(ctx)
+		if errors.Is(err, pihole.ErrSeatsExceeded) && cfg.ReapStaleSessions && c.recoverSeats(ctx) {
+			err = c.authenticate(ctx)
+		}
+		if err != nil {
 			return nil, fmt.Errorf("failed to authenticate: %w", err)
 		}
+
+		if cfg.ReapStaleSessions {
+			if err := c.reapStaleSessions(ctx); err != nil {
+				tflog.SubsystemWarn(c.logContext(ctx), logSubsystem, "Failed to list sessions to reap", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
 	}
 
 	return c, nil
@@ -110,6 +196,46 @@ func (c *Client) ClientManagement() pihole.ClientManagementService {
 	return c.clientMgmt
 }
 
+// Network returns the network table service
+func (c *Client) Network() pihole.NetworkService {
+	return c.network
+}
+
+// DHCP returns the DHCP lease service
+func (c *Client) DHCP() pihole.DHCPService {
+	return c.dhcp
+}
+
+// Stats returns the statistics service
+func (c *Client) Stats() pihole.StatsService {
+	return c.stats
+}
+
+// Queries returns the query log service
+func (c *Client) Queries() pihole.QueryService {
+	return c.queries
+}
+
+// Search returns the domain search service
+func (c *Client) Search() pihole.SearchService {
+	return c.search
+}
+
+// Info returns the system information service
+func (c *Client) Info() pihole.InfoService {
+	return c.info
+}
+
+// Actions returns the action service
+func (c *Client) Actions() pihole.ActionService {
+	return c.actions
+}
+
+// Sessions returns the session service
+func (c *Client) Sessions() pihole.SessionService {
+	return c.sessions
+}
+
 // SessionID returns the current session ID
 func (c *Client) SessionID() string {
 	c.sessionLock.RLock()
@@ -117,22 +243,26 @@ func (c *Client) SessionID() string {
 	return c.sessionID
 }
 
+// RevokedSessions returns the stale sessions revoked after logging in
+func (c *Client) RevokedSessions() []pihole.Session {
+	return c.revoked
+}
+
 // authenticate obtains a session ID from the Pi-hole API
 func (c *Client) authenticate(ctx context.Context) error {
+	ctx = c.logContext(ctx)
+
 	body := map[string]string{"password": c.password}
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/auth", bytes.NewReader(jsonBody))
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/auth", bytes.NewReader(jsonBody))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	if c.userAgent != "" {
-		req.Header.Set("User-Agent", c.userAgent)
-	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -141,7 +271,12 @@ func (c *Client) authenticate(ctx context.Context) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return pihole.ErrAuthFailed
+		apiErr := newAPIError(resp)
+		// The password was accepted, there is just no seat left for a session
+		if errors.Is(apiErr, pihole.ErrSeatsExceeded) {
+			return apiErr
+		}
+		return fmt.Errorf("%w: %w", pihole.ErrAuthFailed, apiErr)
 	}
 
 	var result struct {
@@ -159,47 +294,127 @@ func (c *Client) authenticate(ctx context.Context) error {
 	}
 
 	c.sessionLock.Lock()
+	replaced := c.sessionID
 	c.sessionID = result.Session.SID
 	c.sessionLock.Unlock()
 
+	c.journalSession(ctx, replaced, result.Session.SID)
+
 	return nil
 }
 
-// request performs an authenticated HTTP request
+// newRequest creates a request for an API path with the headers every request
+// carries: the user agent, the custom headers and basic auth
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	for name, value := range c.headers {
+		// Go sends the Host header from the request rather than its headers
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+	if c.basicAuthUsername != "" || c.basicAuthPassword != "" {
+		req.SetBasicAuth(c.basicAuthUsername, c.basicAuthPassword)
+	}
+
+	return req, nil
+}
+
+// request performs an authenticated HTTP request. If Pi-hole rejects a
+// session the client logged in for, as when it timed out or was revoked, the
+// client logs in again and retries the request once.
 func (c *Client) request(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
-	var bodyReader io.Reader
+	ctx = c.logContext(ctx)
+
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
-		if err != nil {
+		var err error
+		if jsonBody, err = json.Marshal(body); err != nil {
 			return nil, err
 		}
+	}
+
+	sid := c.SessionID()
+	resp, err := c.send(ctx, method, path, jsonBody, sid)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || c.externalSession || sid == "" {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	if sid, err = c.reauthenticate(ctx, sid); err != nil {
+		return nil, err
+	}
+	return c.send(ctx, method, path, jsonBody, sid)
+}
+
+// send performs a single HTTP request with the session sid
+func (c *Client) send(ctx context.Context, method, path string, jsonBody []byte, sid string) (*http.Response, error) {
+	var bodyReader io.Reader
+	if jsonBody != nil {
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	req, err := c.newRequest(ctx, method, path, bodyReader)
 	if err != nil {
 		return nil, err
 	}
 
-	if body != nil {
+	if jsonBody != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	if c.userAgent != "" {
-		req.Header.Set("User-Agent", c.userAgent)
-	}
-
-	c.sessionLock.RLock()
-	req.Header.Set(sessionHeader, c.sessionID)
-	c.sessionLock.RUnlock()
+	req.Header.Set(sessionHeader, sid)
 
 	return c.http.Do(req)
 }
 
+// reauthenticate logs in again after Pi-hole rejected the session sid and
+// returns the session to retry with. Requests rejected at the same time
+// share a single new session.
+func (c *Client) reauthenticate(ctx context.Context, sid string) (string, error) {
+	c.authLock.Lock()
+	defer c.authLock.Unlock()
+
+	// Another request already logged in again, or the client logged out
+	if current := c.SessionID(); current != sid {
+		return current, nil
+	}
+
+	tflog.SubsystemWarn(ctx, logSubsystem, "Session rejected, logging in again")
+	if err := c.authenticate(ctx); err != nil {
+		return "", fmt.Errorf("failed to authenticate: %w", err)
+	}
+	return c.SessionID(), nil
+}
+
 // get performs an authenticated GET request
 func (c *Client) get(ctx context.Context, path string) (*http.Response, error) {
 	return c.request(ctx, http.MethodGet, path, nil)
 }
 
+// getJSON performs an authenticated GET request and decodes the response into v
+func (c *Client) getJSON(ctx context.Context, path string, v interface{}) error {
+	resp, err := c.get(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
 // post performs an authenticated POST request
 func (c *Client) post(ctx context.Context, path string, body interface{}) (*http.Response, error) {
 	return c.request(ctx, http.MethodPost, path, body)
@@ -210,6 +425,11 @@ func (c *Client) put(ctx context.Context, path string, body interface{}) (*http.
 	return c.request(ctx, http.MethodPut, path, body)
 }
 
+// patch performs an authenticated PATCH request
+func (c *Client) patch(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+	return c.request(ctx, http.MethodPatch, path, body)
+}
+
 // delete performs an authenticated DELETE request
 func (c *Client) delete(ctx context.Context, path string) (*http.Response, error) {
 	return c.request(ctx, http.MethodDelete, path, nil)
@@ -225,15 +445,14 @@ func (c *Client) Logout(ctx context.Context) error {
 		return nil // No session to logout
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseURL+"/api/auth", nil)
+	ctx = c.logContext(ctx)
+
+	req, err := c.newRequest(ctx, http.MethodDelete, "/api/auth", nil)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set(sessionHeader, sid)
-	if c.userAgent != "" {
-		req.Header.Set("User-Agent", c.userAgent)
-	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -246,5 +465,7 @@ func (c *Client) Logout(ctx context.Context) error {
 	c.sessionID = ""
 	c.sessionLock.Unlock()
 
+	c.journalSession(ctx, sid, "")
+
 	return nil
 }
@@ -14,6 +14,7 @@ const clientsPath = "/api/clients"
 
 type clientService struct {
 	client *Client
+	cache  listCache[pihole.ClientRecord]
 }
 
 // clientAPIRecord represents a client record in the Pi-hole v6 API response
@@ -45,8 +46,14 @@ func (r *clientAPIRecord) toRecord() *pihole.ClientRecord {
 	}
 }
 
-// List returns all client records
+// List returns all client records.
+// The list is served from a per-client snapshot that is reloaded after writes.
 func (s *clientService) List(ctx context.Context) ([]pihole.ClientRecord, error) {
+	return s.cache.get(ctx, s.fetch)
+}
+
+// fetch downloads the current client list from the API
+func (s *clientService) fetch(ctx context.Context) ([]pihole.ClientRecord, error) {
 	resp, err := s.client.get(ctx, clientsPath)
 	if err != nil {
 		return nil, err
@@ -54,7 +61,7 @@ func (s *clientService) List(ctx context.Context) ([]pihole.ClientRecord, error)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var result clientsListResponse
@@ -70,8 +77,22 @@ func (s *clientService) List(ctx context.Context) ([]pihole.ClientRecord, error)
 	return records, nil
 }
 
-// Get returns a specific client by identifier
+// Get returns a specific client by identifier.
+// The cached list is consulted first; identifiers that are not found there
+// verbatim are looked up through the API, which understands Pi-hole's own
+// canonicalisation of client identifiers.
 func (s *clientService) Get(ctx context.Context, clientID string) (*pihole.ClientRecord, error) {
+	records, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range records {
+		if r.Client == clientID {
+			return &r, nil
+		}
+	}
+
 	path := fmt.Sprintf("%s/%s", clientsPath, url.PathEscape(clientID))
 
 	resp, err := s.client.get(ctx, path)
@@ -85,7 +106,7 @@ func (s *clientService) Get(ctx context.Context, clientID string) (*pihole.Clien
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var result clientsListResponse
@@ -102,6 +123,8 @@ func (s *clientService) Get(ctx context.Context, clientID string) (*pihole.Clien
 
 // Create adds a new client record
 func (s *clientService) Create(ctx context.Context, clientID, comment string) (*pihole.ClientRecord, error) {
+	defer s.cache.invalidate()
+
 	body := map[string]string{
 		"client":  clientID,
 		"comment": comment,
@@ -114,7 +137,7 @@ func (s *clientService) Create(ctx context.Context, clientID, comment string) (*
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected status code: %d (expected 200 or 201)", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var result clientsListResponse
@@ -131,6 +154,8 @@ func (s *clientService) Create(ctx context.Context, clientID, comment string) (*
 
 // Update modifies an existing client record
 func (s *clientService) Update(ctx context.Context, clientID, comment string) (*pihole.ClientRecord, error) {
+	defer s.cache.invalidate()
+
 	path := fmt.Sprintf("%s/%s", clientsPath, url.PathEscape(clientID))
 
 	body := map[string]string{
@@ -148,7 +173,7 @@ func (s *clientService) Update(ctx context.Context, clientID, comment string) (*
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d (expected 200)", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var result clientsListResponse
@@ -165,6 +190,8 @@ func (s *clientService) Update(ctx context.Context, clientID, comment string) (*
 
 // Delete removes a client record
 func (s *clientService) Delete(ctx context.Context, clientID string) error {
+	defer s.cache.invalidate()
+
 	path := fmt.Sprintf("%s/%s", clientsPath, url.PathEscape(clientID))
 
 	resp, err := s.client.delete(ctx, path)
@@ -178,7 +205,7 @@ func (s *clientService) Delete(ctx context.Context, clientID string) error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status code: %d (expected 204)", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	return nil
@@ -0,0 +1,357 @@
+package v6
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func newFakeClient(t *testing.T, srv *fake.Server) *Client {
+	t.Helper()
+
+	c, err := NewClient(context.Background(), pihole.Config{
+		BaseURL:   srv.URL,
+		Password:  fake.DefaultPassword,
+		UserAgent: "terraform-provider-pihole/test",
+
+		ConsistencyTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestAuthenticateAndLogout(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c := newFakeClient(t, srv)
+
+	sessions := srv.Sessions()
+	if len(sessions) != 1 || sessions[0].ID != c.SessionID() {
+		t.Fatalf("expected the client's session to be active, got %v", sessions)
+	}
+
+	if err := c.Logout(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(srv.Sessions()); n != 0 {
+		t.Fatalf("expected no sessions after logout, got %d", n)
+	}
+}
+
+func TestAuthenticateWrongPassword(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	_, err := NewClient(context.Background(), pihole.Config{BaseURL: srv.URL, Password: "wrong"})
+	if !errors.Is(err, pihole.ErrAuthFailed) || !errors.Is(err, pihole.ErrUnauthorized) {
+		t.Fatalf("expected ErrAuthFailed and ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestReverseProxy(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	upstream := httputil.NewSingleHostReverseProxy(target)
+
+	// The front serves Pi-hole under /pihole to requests carrying its
+	// credentials, like an authenticating reverse proxy
+	var rejected atomic.Int32
+	front := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		path, prefixed := strings.CutPrefix(r.URL.Path, "/pihole/")
+		if !ok || user != "proxy" || password != "secret" || r.Header.Get("CF-Access-Client-Id") != "client" || !prefixed {
+			rejected.Add(1)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		r.URL.Path = "/" + path
+		upstream.ServeHTTP(w, r)
+	}))
+	defer front.Close()
+
+	c, err := NewClient(context.Background(), pihole.Config{
+		BaseURL:           front.URL + "/pihole/",
+		Password:          fake.DefaultPassword,
+		Headers:           map[string]string{"CF-Access-Client-Id": "client"},
+		BasicAuthUsername: "proxy",
+		BasicAuthPassword: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.LocalDNS().List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Logout(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := rejected.Load(); n > 0 {
+		t.Errorf("expected every request to pass the reverse proxy, %d were rejected", n)
+	}
+	if n := len(srv.Sessions()); n != 0 {
+		t.Errorf("expected the logout to pass the reverse proxy, got %d sessions", n)
+	}
+}
+
+func TestProxyURL(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(&httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			// Requests to a forward proxy carry the absolute target URL
+			proxied.Add(1)
+			r.Out.URL = r.In.URL
+		},
+	})
+	defer proxy.Close()
+
+	c, err := NewClient(context.Background(), pihole.Config{
+		BaseURL:  srv.URL,
+		Password: fake.DefaultPassword,
+		ProxyURL: proxy.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.LocalDNS().List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := proxied.Load(); n != 2 {
+		t.Errorf("expected the login and the request to go through the proxy, got %d requests", n)
+	}
+}
+
+func TestInvalidBaseURL(t *testing.T) {
+	_, err := NewClient(context.Background(), pihole.Config{BaseURL: "pi.hole"})
+	if err == nil || !strings.Contains(err.Error(), "invalid Pi-hole URL") {
+		t.Fatalf("expected an invalid URL error, got %v", err)
+	}
+}
+
+func TestCreateRetriesAlreadyPresent(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c := newFakeClient(t, srv)
+	srv.FailAlreadyPresent(2)
+
+	op := &writeOp{add: true, domain: "retry.lan", entry: "10.0.0.1 retry.lan", ctx: context.Background()}
+	if err := c.dns.writes.add(op); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := c.LocalDNS().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Domain != "retry.lan" {
+		t.Fatalf("unexpected records: %v", records)
+	}
+}
+
+func TestCreateDuplicateFailsFast(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.SetCNAMEs("dup.lan,a.lan")
+
+	c := newFakeClient(t, srv)
+
+	op := &writeOp{add: true, domain: "dup.lan", entry: "dup.lan,b.lan", ctx: context.Background()}
+	start := time.Now()
+	if err := c.cname.writes.add(op); !errors.Is(err, pihole.ErrAlreadyExists) {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+
+	// A conflict that is visible in the array is not retried
+	if elapsed := time.Since(start); elapsed > c.retry.WaitMin {
+		t.Fatalf("duplicate create took %s, expected no retries", elapsed)
+	}
+}
+
+func TestUnauthorizedRequest(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	// The request is rejected again after logging in again
+	c := newFakeClient(t, srv)
+	srv.FailUnauthorized(2)
+
+	_, err := c.LocalCNAME().List(context.Background())
+	if !errors.Is(err, pihole.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+
+	var apiErr *pihole.APIError
+	if !errors.As(err, &apiErr) || apiErr.Key != "unauthorized" {
+		t.Fatalf("expected a decoded API error, got %#v", err)
+	}
+}
+
+func TestReauthenticate(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c := newFakeClient(t, srv)
+	ctx := context.Background()
+
+	// The session is revoked, as by another run reaping it
+	expired := c.SessionID()
+	sessions, err := c.Sessions().List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Sessions().Delete(ctx, sessions[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.LocalDNS().Create(ctx, "reauth.lan", "10.0.0.1", nil); err != nil {
+		t.Fatalf("expected the client to log in again, got %v", err)
+	}
+	if hosts := srv.Hosts(); len(hosts) != 1 {
+		t.Errorf("expected the record to be created, got %v", hosts)
+	}
+
+	remaining := srv.Sessions()
+	if len(remaining) != 1 || remaining[0].ID == expired || remaining[0].ID != c.SessionID() {
+		t.Errorf("expected only a new session of the client, got %v", remaining)
+	}
+}
+
+func TestReauthenticateExternalSession(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	// A session that was passed in is never replaced
+	c, err := NewClient(context.Background(), pihole.Config{
+		BaseURL:   srv.URL,
+		Password:  fake.DefaultPassword,
+		SessionID: srv.AddSession("piholectl/test"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.FailUnauthorized(1)
+
+	if _, err := c.LocalDNS().List(context.Background()); !errors.Is(err, pihole.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+	if n := len(srv.Sessions()); n != 1 {
+		t.Errorf("expected no new session, got %d sessions", n)
+	}
+}
+
+func TestClientLifecycle(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c := newFakeClient(t, srv).ClientManagement()
+	ctx := context.Background()
+
+	if _, err := c.Create(ctx, "192.168.1.10", "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Update(ctx, "192.168.1.10", "second"); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := c.Get(ctx, "192.168.1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Comment != "second" {
+		t.Fatalf("expected updated comment, got %q", record.Comment)
+	}
+
+	if err := c.Delete(ctx, "192.168.1.10"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Get(ctx, "192.168.1.10"); !errors.Is(err, pihole.ErrClientNotFound) {
+		t.Fatalf("expected ErrClientNotFound, got %v", err)
+	}
+}
+
+func TestAuthenticateSeatsExceeded(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.SetMaxSessions(1)
+	srv.AddSession("someone-else")
+
+	start := time.Now()
+	_, err := NewClient(context.Background(), pihole.Config{BaseURL: srv.URL, Password: fake.DefaultPassword})
+	if !errors.Is(err, pihole.ErrSeatsExceeded) {
+		t.Fatalf("expected ErrSeatsExceeded, got %v", err)
+	}
+	if errors.Is(err, pihole.ErrAuthFailed) {
+		t.Errorf("expected exhausted seats not to be reported as a failed login, got %v", err)
+	}
+
+	// Rejected logins are not retried
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the login not to be retried, took %s", elapsed)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	c, err := NewClient(context.Background(), pihole.Config{
+		BaseURL:        srv.URL,
+		Password:       fake.DefaultPassword,
+		RequestTimeout: 50 * time.Millisecond,
+		Retry:          &pihole.RetryPolicy{MaxRetries: 1, WaitMin: time.Millisecond, WaitMax: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.SetLatency(time.Second)
+
+	start := time.Now()
+	if _, err := c.LocalDNS().List(context.Background()); err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the request to be bounded by the timeout, took %s", elapsed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := &Client{retry: pihole.RetryPolicy{WaitMin: 100 * time.Millisecond, WaitMax: time.Second}}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, e := range expected {
+		if actual := c.backoff(i + 1); actual != e {
+			t.Errorf("backoff(%d) = %s, expected %s", i+1, actual, e)
+		}
+	}
+
+	if actual := c.backoff(100); actual != time.Second {
+		t.Errorf("expected large retries to be capped, got %s", actual)
+	}
+}
@@ -4,11 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
@@ -17,6 +16,8 @@ const cnamePath = "/api/config/dns/cnameRecords"
 
 type cnameService struct {
 	client *Client
+	cache  listCache[pihole.CNAMERecord]
+	writes *writeBatcher
 }
 
 // cnameListResponse is the API response for listing CNAME records
@@ -28,8 +29,47 @@ type cnameListResponse struct {
 	} `json:"config"`
 }
 
-// List returns all CNAME records
+// newCNAMEService creates the CNAME record service for c
+func newCNAMEService(c *Client) *cnameService {
+	s := &cnameService{client: c}
+	s.writes = &writeBatcher{
+		client:    c,
+		key:       "cnameRecords",
+		fetch:     s.fetchCNAMEs,
+		domainsOf: cnameDomains,
+		without:   cnameWithout,
+		conflicts: func(existing []string, entry string) bool {
+			// dnsmasq rejects a second CNAME for the same domain
+			domains := cnameDomains(entry)
+			return slices.ContainsFunc(existing, func(e string) bool {
+				return slices.ContainsFunc(cnameDomains(e), func(d string) bool { return slices.Contains(domains, d) })
+			})
+		},
+		put:     s.putEntry,
+		del:     s.deleteEntry,
+		flushed: s.cache.invalidate,
+	}
+	return s
+}
+
+// List returns all CNAME records.
+// The list is served from a per-client snapshot that is reloaded after writes.
 func (s *cnameService) List(ctx context.Context) ([]pihole.CNAMERecord, error) {
+	return s.cache.get(ctx, s.fetch)
+}
+
+// fetch downloads and parses the current dns.cnameRecords list from the API
+func (s *cnameService) fetch(ctx context.Context) ([]pihole.CNAMERecord, error) {
+	cnames, err := s.fetchCNAMEs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCNAMEs(cnames), nil
+}
+
+// fetchCNAMEs downloads the raw "domain,target" entries of dns.cnameRecords
+func (s *cnameService) fetchCNAMEs(ctx context.Context) ([]string, error) {
 	resp, err := s.client.get(ctx, cnamePath)
 	if err != nil {
 		return nil, err
@@ -37,7 +77,7 @@ func (s *cnameService) List(ctx context.Context) ([]pihole.CNAMERecord, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var result cnameListResponse
@@ -45,7 +85,7 @@ func (s *cnameService) List(ctx context.Context) ([]pihole.CNAMERecord, error) {
 		return nil, err
 	}
 
-	return parseCNAMEs(result.Config.DNS.CNAMERecords), nil
+	return result.Config.DNS.CNAMERecords, nil
 }
 
 // Get returns a specific CNAME record by domain
@@ -65,70 +105,57 @@ func (s *cnameService) Get(ctx context.Context, domain string) (*pihole.CNAMERec
 }
 
 // Create adds a new CNAME record.
-// Includes retry logic for "duplicate CNAME" errors that can occur during
-// ForceNew operations when dnsmasq hasn't fully processed a prior delete.
+// Concurrent creates and deletes are coalesced into a single config update.
 func (s *cnameService) Create(ctx context.Context, domain, target string, opts *pihole.CreateOptions) (*pihole.CNAMERecord, error) {
-	path := fmt.Sprintf("%s/%s", cnamePath, url.PathEscape(domain+","+target))
+	defer s.cache.invalidate()
 
-	// Append force parameter if requested
-	if opts != nil && opts.Force {
-		path += "?force=true"
+	op := &writeOp{
+		add:    true,
+		domain: domain,
+		entry:  domain + "," + target,
+		force:  opts != nil && opts.Force,
 	}
 
-	const maxRetries = 5
-	var lastErr error
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff: 200ms, 400ms, 800ms, 1600ms
-			delay := time.Duration(200<<uint(attempt-1)) * time.Millisecond
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(delay):
-			}
-		}
-
-		resp, err := s.client.put(ctx, path, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		if resp.StatusCode == http.StatusCreated {
-			resp.Body.Close()
-			return &pihole.CNAMERecord{Domain: domain, Target: target}, nil
-		}
-
-		body, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-
-		// Check if this is a retryable error (duplicate/conflict during ForceNew)
-		bodyStr := string(body)
-		if resp.StatusCode == http.StatusBadRequest && (strings.Contains(bodyStr, "duplicate CNAME") || strings.Contains(bodyStr, "already present")) {
-			lastErr = fmt.Errorf("item already present (attempt %d/%d): %s", attempt+1, maxRetries, bodyStr)
-			continue
-		}
-
-		// Non-retryable error
-		return nil, fmt.Errorf("unexpected status code: %d (expected 201): %s", resp.StatusCode, bodyStr)
+	if err := s.writes.submit(ctx, op); err != nil {
+		return nil, err
 	}
 
-	return nil, lastErr
+	return &pihole.CNAMERecord{Domain: domain, Target: target}, nil
 }
 
 // Delete removes a CNAME record.
 // Returns nil if the record doesn't exist (idempotent delete).
 func (s *cnameService) Delete(ctx context.Context, domain string) error {
-	// First get the record to find its target
-	record, err := s.Get(ctx, domain)
+	defer s.cache.invalidate()
+
+	return s.writes.submit(ctx, &writeOp{domain: domain})
+}
+
+// putEntry adds a single "domain,target" entry through the per-entry endpoint
+func (s *cnameService) putEntry(ctx context.Context, entry string, force bool) error {
+	path := fmt.Sprintf("%s/%s", cnamePath, url.PathEscape(entry))
+
+	// Append force parameter if requested
+	if force {
+		path += "?force=true"
+	}
+
+	resp, err := s.client.put(ctx, path, nil)
 	if err != nil {
-		// If record not found, delete is already done
-		if err == pihole.ErrCNAMENotFound {
-			return nil
-		}
 		return err
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
 
-	path := fmt.Sprintf("%s/%s", cnamePath, url.PathEscape(domain+","+record.Target))
+// deleteEntry removes a single "domain,target" entry through the per-entry endpoint
+func (s *cnameService) deleteEntry(ctx context.Context, entry string) error {
+	path := fmt.Sprintf("%s/%s", cnamePath, url.PathEscape(entry))
 
 	resp, err := s.client.delete(ctx, path)
 	if err != nil {
@@ -138,23 +165,48 @@ func (s *cnameService) Delete(ctx context.Context, domain string) error {
 
 	// 204 = deleted, 404 = already gone (both are success)
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		return fmt.Errorf("unexpected status code: %d (expected 204)", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	return nil
 }
 
+// cnameDomains returns the domains of a "domain[,domain...],target[,ttl]"
+// entry
+func cnameDomains(entry string) []string {
+	records := pihole.ParseCNAMELine(entry)
+	domains := make([]string, 0, len(records))
+	for _, r := range records {
+		domains = append(domains, r.Domain)
+	}
+	return domains
+}
+
+// cnameWithout returns a "domain[,domain...],target[,ttl]" entry without
+// domain, or "" if it has no other domain
+func cnameWithout(entry, domain string) string {
+	records := pihole.ParseCNAMELine(entry)
+
+	// The domains lead the entry, followed by the target and TTL
+	fields := strings.Split(entry, ",")
+	kept := make([]string, 0, len(fields))
+	for i, field := range fields {
+		if i < len(records) && strings.TrimSpace(field) == domain {
+			continue
+		}
+		kept = append(kept, field)
+	}
+	if len(fields)-len(kept) >= len(records) {
+		return ""
+	}
+	return strings.Join(kept, ",")
+}
+
 // parseCNAMEs converts "domain,target" strings to CNAMERecord structs
 func parseCNAMEs(cnames []string) []pihole.CNAMERecord {
 	records := make([]pihole.CNAMERecord, 0, len(cnames))
 	for _, c := range cnames {
-		parts := strings.SplitN(c, ",", 2)
-		if len(parts) == 2 {
-			records = append(records, pihole.CNAMERecord{
-				Domain: parts[0],
-				Target: parts[1],
-			})
-		}
+		records = append(records, pihole.ParseCNAMELine(c)...)
 	}
 	return records
 }
@@ -0,0 +1,79 @@
+package v6
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+const dhcpLeasesPath = "/api/dhcp/leases"
+
+type dhcpService struct {
+	client *Client
+}
+
+// dhcpLeaseAPIRecord represents a lease in the Pi-hole v6 API response
+type dhcpLeaseAPIRecord struct {
+	Expires  int64  `json:"expires"`
+	Name     string `json:"name"`
+	HWAddr   string `json:"hwaddr"`
+	IP       string `json:"ip"`
+	ClientID string `json:"clientid"`
+}
+
+// dhcpLeasesResponse is the API response for listing DHCP leases
+type dhcpLeasesResponse struct {
+	Leases []dhcpLeaseAPIRecord `json:"leases"`
+}
+
+// List returns all active DHCP leases
+func (s *dhcpService) List(ctx context.Context) ([]pihole.DHCPLease, error) {
+	resp, err := s.client.get(ctx, dhcpLeasesPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result dhcpLeasesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	leases := make([]pihole.DHCPLease, 0, len(result.Leases))
+	for _, l := range result.Leases {
+		leases = append(leases, pihole.DHCPLease{
+			IP:       l.IP,
+			HWAddr:   l.HWAddr,
+			Name:     l.Name,
+			ClientID: l.ClientID,
+			Expires:  l.Expires,
+		})
+	}
+
+	return leases, nil
+}
+
+// Delete revokes the lease of ip.
+// Returns nil if there is no such lease (idempotent delete).
+func (s *dhcpService) Delete(ctx context.Context, ip string) error {
+	resp, err := s.client.delete(ctx, fmt.Sprintf("%s/%s", dhcpLeasesPath, url.PathEscape(ip)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 204 = deleted, 404 = already gone (both are success)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package v6
+
+import (
+	"context"
+	"testing"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestDHCPLeases(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.AddLease(fake.Lease{IP: "192.168.1.50", HWAddr: "aa:bb:cc:dd:ee:01", Name: "laptop", ClientID: "01:aa:bb:cc:dd:ee:01", Expires: 1700000000})
+	srv.AddLease(fake.Lease{IP: "192.168.1.51", HWAddr: "aa:bb:cc:dd:ee:02"})
+
+	c := newFakeClient(t, srv).DHCP()
+	ctx := context.Background()
+
+	leases, err := c.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leases) != 2 || leases[0].Name != "laptop" || leases[0].ClientID != "01:aa:bb:cc:dd:ee:01" || leases[0].Expires != 1700000000 {
+		t.Fatalf("unexpected leases: %+v", leases)
+	}
+
+	if err := c.Delete(ctx, "192.168.1.51"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(ctx, "192.168.1.51"); err != nil {
+		t.Fatalf("deleting a missing lease should succeed, got %v", err)
+	}
+	if n := len(srv.Leases()); n != 1 {
+		t.Errorf("expected 1 lease after delete, got %d", n)
+	}
+}
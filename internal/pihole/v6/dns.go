@@ -4,11 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
-	"time"
 
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
@@ -17,6 +16,8 @@ const dnsHostsPath = "/api/config/dns/hosts"
 
 type dnsService struct {
 	client *Client
+	cache  listCache[pihole.DNSRecord]
+	writes *writeBatcher
 }
 
 // dnsListResponse is the API response for listing DNS records
@@ -28,8 +29,43 @@ type dnsListResponse struct {
 	} `json:"config"`
 }
 
-// List returns all local DNS records
+// newDNSService creates the DNS record service for c
+func newDNSService(c *Client) *dnsService {
+	s := &dnsService{client: c}
+	s.writes = &writeBatcher{
+		client:    c,
+		key:       "hosts",
+		fetch:     s.fetchHosts,
+		domainsOf: hostDomains,
+		without:   hostWithout,
+		conflicts: func(existing []string, entry string) bool {
+			return slices.Contains(existing, entry)
+		},
+		put:     s.putEntry,
+		del:     s.deleteEntry,
+		flushed: s.cache.invalidate,
+	}
+	return s
+}
+
+// List returns all local DNS records.
+// The list is served from a per-client snapshot that is reloaded after writes.
 func (s *dnsService) List(ctx context.Context) ([]pihole.DNSRecord, error) {
+	return s.cache.get(ctx, s.fetch)
+}
+
+// fetch downloads and parses the current dns.hosts list from the API
+func (s *dnsService) fetch(ctx context.Context) ([]pihole.DNSRecord, error) {
+	hosts, err := s.fetchHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDNSHosts(hosts), nil
+}
+
+// fetchHosts downloads the raw "IP domain" entries of dns.hosts
+func (s *dnsService) fetchHosts(ctx context.Context) ([]string, error) {
 	resp, err := s.client.get(ctx, dnsHostsPath)
 	if err != nil {
 		return nil, err
@@ -37,7 +73,7 @@ func (s *dnsService) List(ctx context.Context) ([]pihole.DNSRecord, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	var result dnsListResponse
@@ -45,7 +81,7 @@ func (s *dnsService) List(ctx context.Context) ([]pihole.DNSRecord, error) {
 		return nil, err
 	}
 
-	return parseDNSHosts(result.Config.DNS.Hosts), nil
+	return result.Config.DNS.Hosts, nil
 }
 
 // Get returns a specific DNS record by domain
@@ -65,80 +101,58 @@ func (s *dnsService) Get(ctx context.Context, domain string) (*pihole.DNSRecord,
 }
 
 // Create adds a new DNS record.
-// If opts.Force is true and the record already exists, it will be deleted first.
-// Includes retry logic for transient errors that can occur during
-// ForceNew operations when Pi-hole hasn't fully processed a prior delete.
+// If opts.Force is true and the record already exists, it will be deleted first;
+// the delete and create are applied together in the same config update.
+// Concurrent creates and deletes are coalesced into a single config update.
 func (s *dnsService) Create(ctx context.Context, domain, ip string, opts *pihole.CreateOptions) (*pihole.DNSRecord, error) {
-	// If force is requested and record exists, delete it first
-	if opts != nil && opts.Force {
-		existing, err := s.Get(ctx, domain)
-		if err == nil && existing != nil {
-			// Record exists - delete it first
-			if delErr := s.Delete(ctx, domain); delErr != nil {
-				return nil, fmt.Errorf("force delete failed: %w", delErr)
-			}
-			// Brief pause to let Pi-hole process the delete
-			time.Sleep(100 * time.Millisecond)
-		}
-		// If err != nil (not found), that's fine - proceed with create
-	}
-
-	path := fmt.Sprintf("%s/%s", dnsHostsPath, url.PathEscape(ip+" "+domain))
-
-	const maxRetries = 5
-	var lastErr error
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff: 200ms, 400ms, 800ms, 1600ms
-			delay := time.Duration(200<<uint(attempt-1)) * time.Millisecond
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(delay):
-			}
-		}
-
-		resp, err := s.client.put(ctx, path, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		if resp.StatusCode == http.StatusCreated {
-			resp.Body.Close()
-			return &pihole.DNSRecord{Domain: domain, IP: ip}, nil
-		}
+	defer s.cache.invalidate()
 
-		body, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
+	var forceDelete *writeOp
+	ops := make([]*writeOp, 0, 2)
+	if opts != nil && opts.Force {
+		forceDelete = &writeOp{domain: domain}
+		ops = append(ops, forceDelete)
+	}
+	ops = append(ops, &writeOp{add: true, domain: domain, entry: ip + " " + domain})
 
-		// Check if this is a retryable error (duplicate/conflict during ForceNew)
-		bodyStr := string(body)
-		if resp.StatusCode == http.StatusBadRequest && strings.Contains(bodyStr, "already present") {
-			lastErr = fmt.Errorf("item already present (attempt %d/%d): %s", attempt+1, maxRetries, bodyStr)
-			continue
+	if err := s.writes.submit(ctx, ops...); err != nil {
+		if forceDelete != nil && forceDelete.err != nil {
+			return nil, fmt.Errorf("force delete failed: %w", forceDelete.err)
 		}
-
-		// Non-retryable error
-		return nil, fmt.Errorf("unexpected status code: %d (expected 201): %s", resp.StatusCode, bodyStr)
+		return nil, err
 	}
 
-	return nil, lastErr
+	return &pihole.DNSRecord{Domain: domain, IP: ip}, nil
 }
 
 // Delete removes a DNS record.
 // Returns nil if the record doesn't exist (idempotent delete).
 func (s *dnsService) Delete(ctx context.Context, domain string) error {
-	// First get the record to find its IP
-	record, err := s.Get(ctx, domain)
+	defer s.cache.invalidate()
+
+	return s.writes.submit(ctx, &writeOp{domain: domain})
+}
+
+// putEntry adds a single "IP domain" entry through the per-entry endpoint
+func (s *dnsService) putEntry(ctx context.Context, entry string, _ bool) error {
+	path := fmt.Sprintf("%s/%s", dnsHostsPath, url.PathEscape(entry))
+
+	resp, err := s.client.put(ctx, path, nil)
 	if err != nil {
-		// If record not found, delete is already done
-		if err == pihole.ErrDNSNotFound {
-			return nil
-		}
 		return err
 	}
+	defer resp.Body.Close()
 
-	path := fmt.Sprintf("%s/%s", dnsHostsPath, url.PathEscape(record.IP+" "+domain))
+	if resp.StatusCode != http.StatusCreated {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// deleteEntry removes a single "IP domain" entry through the per-entry endpoint
+func (s *dnsService) deleteEntry(ctx context.Context, entry string) error {
+	path := fmt.Sprintf("%s/%s", dnsHostsPath, url.PathEscape(entry))
 
 	resp, err := s.client.delete(ctx, path)
 	if err != nil {
@@ -148,23 +162,46 @@ func (s *dnsService) Delete(ctx context.Context, domain string) error {
 
 	// 204 = deleted, 404 = already gone (both are success)
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		return fmt.Errorf("unexpected status code: %d (expected 204)", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	return nil
 }
 
+// hostDomains returns the domains of an "IP domain [domain...]" entry
+func hostDomains(entry string) []string {
+	records := pihole.ParseHostsLine(entry)
+	domains := make([]string, 0, len(records))
+	for _, r := range records {
+		domains = append(domains, r.Domain)
+	}
+	return domains
+}
+
+// hostWithout returns an "IP domain [domain...]" entry without domain, or ""
+// if it has no other domain
+func hostWithout(entry, domain string) string {
+	records := pihole.ParseHostsLine(entry)
+	fields := make([]string, 0, len(records)+1)
+	for _, r := range records {
+		if len(fields) == 0 {
+			fields = append(fields, r.IP)
+		}
+		if r.Domain != domain {
+			fields = append(fields, r.Domain)
+		}
+	}
+	if len(fields) < 2 {
+		return ""
+	}
+	return strings.Join(fields, " ")
+}
+
 // parseDNSHosts converts "IP domain" strings to DNSRecord structs
 func parseDNSHosts(hosts []string) []pihole.DNSRecord {
 	records := make([]pihole.DNSRecord, 0, len(hosts))
 	for _, h := range hosts {
-		parts := strings.SplitN(h, " ", 2)
-		if len(parts) == 2 {
-			records = append(records, pihole.DNSRecord{
-				IP:     parts[0],
-				Domain: parts[1],
-			})
-		}
+		records = append(records, pihole.ParseHostsLine(h)...)
 	}
 	return records
 }
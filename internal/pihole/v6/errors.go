@@ -0,0 +1,54 @@
+package v6
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// maxErrorBody bounds how much of an error response is read
+const maxErrorBody = 64 << 10
+
+// errorResponse is Pi-hole's JSON error envelope
+type errorResponse struct {
+	Error *struct {
+		Key     string  `json:"key"`
+		Message string  `json:"message"`
+		Hint    *string `json:"hint"`
+	} `json:"error"`
+
+	// Failed logins report the reason in the session object instead
+	Session *struct {
+		Message string `json:"message"`
+	} `json:"session"`
+}
+
+// newAPIError builds a *pihole.APIError from an unsuccessful response.
+// The response body is consumed but not closed.
+func newAPIError(resp *http.Response) error {
+	apiErr := &pihole.APIError{StatusCode: resp.StatusCode}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	var result errorResponse
+	if err := json.Unmarshal(body, &result); err == nil {
+		switch {
+		case result.Error != nil:
+			apiErr.Key = result.Error.Key
+			apiErr.Message = result.Error.Message
+			if result.Error.Hint != nil {
+				apiErr.Hint = *result.Error.Hint
+			}
+		case result.Session != nil:
+			apiErr.Message = result.Session.Message
+		}
+		return apiErr
+	}
+
+	// Not JSON, e.g. an error page from a reverse proxy
+	apiErr.Message = strings.TrimSpace(string(body))
+	return apiErr
+}
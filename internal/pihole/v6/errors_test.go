@@ -0,0 +1,79 @@
+package v6
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+func TestNewAPIError(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		body     string
+		expected pihole.APIError
+		is       error
+	}{
+		{
+			name:     "already present",
+			status:   http.StatusBadRequest,
+			body:     `{"error":{"key":"bad_request","message":"Item already present","hint":"Uniqueness of items is enforced"},"took":0.001}`,
+			expected: pihole.APIError{StatusCode: 400, Key: "bad_request", Message: "Item already present", Hint: "Uniqueness of items is enforced"},
+			is:       pihole.ErrAlreadyExists,
+		},
+		{
+			name:     "seats exceeded",
+			status:   http.StatusTooManyRequests,
+			body:     `{"error":{"key":"api_seats_exceeded","message":"API seats exceeded","hint":"increase webserver.api.max_sessions"}}`,
+			expected: pihole.APIError{StatusCode: 429, Key: "api_seats_exceeded", Message: "API seats exceeded", Hint: "increase webserver.api.max_sessions"},
+			is:       pihole.ErrSeatsExceeded,
+		},
+		{
+			name:     "null hint",
+			status:   http.StatusUnauthorized,
+			body:     `{"error":{"key":"unauthorized","message":"Unauthorized","hint":null}}`,
+			expected: pihole.APIError{StatusCode: 401, Key: "unauthorized", Message: "Unauthorized"},
+			is:       pihole.ErrUnauthorized,
+		},
+		{
+			name:     "failed login",
+			status:   http.StatusUnauthorized,
+			body:     `{"session":{"valid":false,"totp":false,"sid":null,"validity":-1,"message":"password incorrect"}}`,
+			expected: pihole.APIError{StatusCode: 401, Message: "password incorrect"},
+			is:       pihole.ErrUnauthorized,
+		},
+		{
+			name:     "not json",
+			status:   http.StatusBadGateway,
+			body:     "<html>Bad Gateway</html>\n",
+			expected: pihole.APIError{StatusCode: 502, Message: "<html>Bad Gateway</html>"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(tc.status)
+			_, _ = rec.WriteString(tc.body)
+
+			err := newAPIError(rec.Result())
+
+			var apiErr *pihole.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *pihole.APIError, got %T", err)
+			}
+			if *apiErr != tc.expected {
+				t.Fatalf("expected %#v, got %#v", tc.expected, *apiErr)
+			}
+			if tc.is != nil && !errors.Is(err, tc.is) {
+				t.Fatalf("expected error to match %v", tc.is)
+			}
+			if errors.Is(err, pihole.ErrBadRequest) != (tc.status == http.StatusBadRequest) {
+				t.Fatal("ErrBadRequest must match exactly the 400 responses")
+			}
+		})
+	}
+}
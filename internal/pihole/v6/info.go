@@ -0,0 +1,259 @@
+package v6
+
+import (
+	"context"
+	"time"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+const infoPath = "/api/info"
+
+type infoService struct {
+	client *Client
+}
+
+// systemInfoResponse is the API response for system information
+type systemInfoResponse struct {
+	System struct {
+		Uptime int64 `json:"uptime"`
+		Memory struct {
+			RAM struct {
+				Total       int64   `json:"total"`
+				Used        int64   `json:"used"`
+				Available   int64   `json:"available"`
+				PercentUsed float64 `json:"%used"`
+			} `json:"ram"`
+			Swap struct {
+				Total       int64   `json:"total"`
+				Used        int64   `json:"used"`
+				PercentUsed float64 `json:"%used"`
+			} `json:"swap"`
+		} `json:"memory"`
+		Procs int `json:"procs"`
+		CPU   struct {
+			NProcs  int     `json:"nprocs"`
+			Percent float64 `json:"%cpu"`
+			Load    struct {
+				Raw []float64 `json:"raw"`
+			} `json:"load"`
+		} `json:"cpu"`
+	} `json:"system"`
+}
+
+// hostInfoResponse is the API response for host information
+type hostInfoResponse struct {
+	Host struct {
+		Uname struct {
+			Nodename string `json:"nodename"`
+			Sysname  string `json:"sysname"`
+			Release  string `json:"release"`
+			Version  string `json:"version"`
+			Machine  string `json:"machine"`
+		} `json:"uname"`
+		Model string `json:"model"`
+	} `json:"host"`
+}
+
+// ftlInfoResponse is the API response for FTL information
+type ftlInfoResponse struct {
+	FTL struct {
+		Database struct {
+			Gravity int `json:"gravity"`
+		} `json:"database"`
+		PrivacyLevel   int     `json:"privacy_level"`
+		QueryFrequency float64 `json:"query_frequency"`
+		Clients        struct {
+			Total  int `json:"total"`
+			Active int `json:"active"`
+		} `json:"clients"`
+		PID              int     `json:"pid"`
+		Uptime           int64   `json:"uptime"`
+		MemoryPercent    float64 `json:"%mem"`
+		CPUPercent       float64 `json:"%cpu"`
+		AllowDestructive bool    `json:"allow_destructive"`
+	} `json:"ftl"`
+}
+
+// databaseInfoResponse is the API response for database information
+type databaseInfoResponse struct {
+	Size              int64  `json:"size"`
+	MTime             int64  `json:"mtime"`
+	Queries           int    `json:"queries"`
+	EarliestTimestamp int64  `json:"earliest_timestamp"`
+	SQLiteVersion     string `json:"sqlite_version"`
+}
+
+// sensorsInfoResponse is the API response for sensor readings
+type sensorsInfoResponse struct {
+	Sensors struct {
+		List []struct {
+			Name  string `json:"name"`
+			Path  string `json:"path"`
+			Temps []struct {
+				Name  string  `json:"name"`
+				Value float64 `json:"value"`
+				Max   float64 `json:"max"`
+				Crit  float64 `json:"crit"`
+			} `json:"temps"`
+		} `json:"list"`
+		CPUTemp  float64 `json:"cpu_temp"`
+		HotLimit float64 `json:"hot_limit"`
+		Unit     string  `json:"unit"`
+	} `json:"sensors"`
+}
+
+// componentVersionResponse is the installed and remote version of a component
+type componentVersionResponse struct {
+	Local struct {
+		Version string `json:"version"`
+		Branch  string `json:"branch"`
+		Hash    string `json:"hash"`
+	} `json:"local"`
+	Remote struct {
+		Version string `json:"version"`
+	} `json:"remote"`
+}
+
+// versionInfoResponse is the API response for component versions
+type versionInfoResponse struct {
+	Version struct {
+		Core   componentVersionResponse `json:"core"`
+		Web    componentVersionResponse `json:"web"`
+		FTL    componentVersionResponse `json:"ftl"`
+		Docker struct {
+			Local string `json:"local"`
+		} `json:"docker"`
+	} `json:"version"`
+}
+
+// toVersion converts an API component version to a pihole.ComponentVersion
+func (r *componentVersionResponse) toVersion() pihole.ComponentVersion {
+	return pihole.ComponentVersion{
+		Version:       r.Local.Version,
+		Branch:        r.Local.Branch,
+		Hash:          r.Local.Hash,
+		RemoteVersion: r.Remote.Version,
+	}
+}
+
+// System returns the resource usage of the host
+func (s *infoService) System(ctx context.Context) (*pihole.SystemInfo, error) {
+	var result systemInfoResponse
+	if err := s.client.getJSON(ctx, infoPath+"/system", &result); err != nil {
+		return nil, err
+	}
+
+	sys := result.System
+	return &pihole.SystemInfo{
+		Uptime:            time.Duration(sys.Uptime) * time.Second,
+		Processes:         sys.Procs,
+		MemoryTotal:       sys.Memory.RAM.Total,
+		MemoryUsed:        sys.Memory.RAM.Used,
+		MemoryAvailable:   sys.Memory.RAM.Available,
+		MemoryPercentUsed: sys.Memory.RAM.PercentUsed,
+		SwapTotal:         sys.Memory.Swap.Total,
+		SwapUsed:          sys.Memory.Swap.Used,
+		SwapPercentUsed:   sys.Memory.Swap.PercentUsed,
+		CPUCores:          sys.CPU.NProcs,
+		CPUPercent:        sys.CPU.Percent,
+		Load:              sys.CPU.Load.Raw,
+	}, nil
+}
+
+// Host identifies the host
+func (s *infoService) Host(ctx context.Context) (*pihole.HostInfo, error) {
+	var result hostInfoResponse
+	if err := s.client.getJSON(ctx, infoPath+"/host", &result); err != nil {
+		return nil, err
+	}
+
+	uname := result.Host.Uname
+	return &pihole.HostInfo{
+		Hostname: uname.Nodename,
+		Sysname:  uname.Sysname,
+		Release:  uname.Release,
+		Version:  uname.Version,
+		Machine:  uname.Machine,
+		Model:    result.Host.Model,
+	}, nil
+}
+
+// FTL describes the running pihole-FTL process
+func (s *infoService) FTL(ctx context.Context) (*pihole.FTLInfo, error) {
+	var result ftlInfoResponse
+	if err := s.client.getJSON(ctx, infoPath+"/ftl", &result); err != nil {
+		return nil, err
+	}
+
+	ftl := result.FTL
+	return &pihole.FTLInfo{
+		PID: ftl.PID,
+		// FTL reports its uptime in milliseconds
+		Uptime:           time.Duration(ftl.Uptime) * time.Millisecond,
+		PrivacyLevel:     ftl.PrivacyLevel,
+		MemoryPercent:    ftl.MemoryPercent,
+		CPUPercent:       ftl.CPUPercent,
+		QueryFrequency:   ftl.QueryFrequency,
+		ActiveClients:    ftl.Clients.Active,
+		TotalClients:     ftl.Clients.Total,
+		GravityDomains:   ftl.Database.Gravity,
+		AllowDestructive: ftl.AllowDestructive,
+	}, nil
+}
+
+// Database describes the long-term query database
+func (s *infoService) Database(ctx context.Context) (*pihole.DatabaseInfo, error) {
+	var result databaseInfoResponse
+	if err := s.client.getJSON(ctx, infoPath+"/database", &result); err != nil {
+		return nil, err
+	}
+
+	return &pihole.DatabaseInfo{
+		Size:              result.Size,
+		Queries:           result.Queries,
+		EarliestTimestamp: result.EarliestTimestamp,
+		ModifiedAt:        result.MTime,
+		SQLiteVersion:     result.SQLiteVersion,
+	}, nil
+}
+
+// Sensors returns the temperature readings of the host
+func (s *infoService) Sensors(ctx context.Context) (*pihole.SensorsInfo, error) {
+	var result sensorsInfoResponse
+	if err := s.client.getJSON(ctx, infoPath+"/sensors", &result); err != nil {
+		return nil, err
+	}
+
+	info := &pihole.SensorsInfo{
+		CPUTemp:  result.Sensors.CPUTemp,
+		HotLimit: result.Sensors.HotLimit,
+		Unit:     result.Sensors.Unit,
+		Sensors:  make([]pihole.Sensor, 0, len(result.Sensors.List)),
+	}
+
+	for _, sensor := range result.Sensors.List {
+		temps := make([]pihole.SensorTemp, 0, len(sensor.Temps))
+		for _, t := range sensor.Temps {
+			temps = append(temps, pihole.SensorTemp{Name: t.Name, Value: t.Value, Max: t.Max, Crit: t.Crit})
+		}
+		info.Sensors = append(info.Sensors, pihole.Sensor{Name: sensor.Name, Path: sensor.Path, Temps: temps})
+	}
+
+	return info, nil
+}
+
+// Version returns the versions of the Pi-hole components
+func (s *infoService) Version(ctx context.Context) (*pihole.VersionInfo, error) {
+	var result versionInfoResponse
+	if err := s.client.getJSON(ctx, infoPath+"/version", &result); err != nil {
+		return nil, err
+	}
+
+	return &pihole.VersionInfo{
+		Core:   result.Version.Core.toVersion(),
+		Web:    result.Version.Web.toVersion(),
+		FTL:    result.Version.FTL.toVersion(),
+		Docker: result.Version.Docker.Local,
+	}, nil
+}
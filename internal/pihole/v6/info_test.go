@@ -0,0 +1,96 @@
+package v6
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestInfo(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.SetInfo(fake.Info{
+		Uptime:          7200,
+		MemoryTotal:     1000,
+		MemoryUsed:      250,
+		MemoryAvailable: 700,
+		CPUCores:        4,
+		Load:            []float64{0.5, 0.25, 0.125},
+
+		Hostname: "pihole-1",
+		Sysname:  "Linux",
+		Machine:  "aarch64",
+		Model:    "Raspberry Pi 4 Model B",
+
+		FTLPID:          1234,
+		FTLUptimeMillis: 90000,
+		FTLVersion:      "v6.1",
+		PrivacyLevel:    2,
+
+		DatabaseSize:    4096,
+		DatabaseQueries: 17,
+		SQLiteVersion:   "3.46.0",
+
+		CPUTemp:  48.5,
+		HotLimit: 60,
+		Sensors: []fake.Sensor{{
+			Name:  "cpu_thermal",
+			Path:  "/sys/class/thermal/thermal_zone0",
+			Temps: []fake.SensorTemp{{Name: "temp1", Value: 48.5}},
+		}},
+	})
+
+	c := newFakeClient(t, srv).Info()
+	ctx := context.Background()
+
+	sys, err := c.System(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sys.Uptime != 2*time.Hour || sys.MemoryPercentUsed != 25 || sys.CPUCores != 4 || len(sys.Load) != 3 || sys.Load[2] != 0.125 {
+		t.Errorf("unexpected system info: %+v", sys)
+	}
+
+	host, err := c.Host(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.Hostname != "pihole-1" || host.Machine != "aarch64" || host.Model != "Raspberry Pi 4 Model B" {
+		t.Errorf("unexpected host info: %+v", host)
+	}
+
+	ftl, err := c.FTL(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ftl.PID != 1234 || ftl.Uptime != 90*time.Second || ftl.PrivacyLevel != 2 {
+		t.Errorf("unexpected FTL info: %+v", ftl)
+	}
+
+	db, err := c.Database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Size != 4096 || db.Queries != 17 || db.SQLiteVersion != "3.46.0" {
+		t.Errorf("unexpected database info: %+v", db)
+	}
+
+	sensors, err := c.Sensors(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sensors.CPUTemp != 48.5 || sensors.Unit != "C" || len(sensors.Sensors) != 1 || len(sensors.Sensors[0].Temps) != 1 {
+		t.Errorf("unexpected sensors: %+v", sensors)
+	}
+
+	version, err := c.Version(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.FTL.Version != "v6.1" || version.Docker != "" {
+		t.Errorf("unexpected versions: %+v", version)
+	}
+}
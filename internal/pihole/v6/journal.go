@@ -0,0 +1,73 @@
+package v6
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sessionJournal records the sessions a client logged in for until it logs
+// out of them, in a directory shared by the runs on one machine. The sessions
+// of runs that crashed stay in it, so that a later run that is rejected for
+// lack of API seats can borrow one to list and revoke stale sessions with.
+// Each session is a file of its own, so concurrent runs never rewrite each
+// other's entries.
+type sessionJournal struct {
+	dir string
+}
+
+// newSessionJournal returns the journal of the Pi-hole at baseURL in dir
+func newSessionJournal(dir, baseURL string) *sessionJournal {
+	return &sessionJournal{dir: filepath.Join(dir, journalName(baseURL))}
+}
+
+// journalName returns a file name derived from s that does not reveal it
+func journalName(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// add records the session sid
+func (j *sessionJournal) add(sid string) error {
+	if err := os.MkdirAll(j.dir, 0o700); err != nil {
+		return err
+	}
+	// Session IDs are credentials
+	return os.WriteFile(filepath.Join(j.dir, journalName(sid)), []byte(sid), 0o600)
+}
+
+// remove forgets the session sid
+func (j *sessionJournal) remove(sid string) error {
+	err := os.Remove(filepath.Join(j.dir, journalName(sid)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// sessions returns the recorded sessions
+func (j *sessionJournal) sessions() ([]string, error) {
+	entries, err := os.ReadDir(j.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(j.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if sid := strings.TrimSpace(string(data)); sid != "" {
+			sids = append(sids, sid)
+		}
+	}
+	return sids, nil
+}
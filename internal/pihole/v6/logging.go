@@ -0,0 +1,143 @@
+package v6
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// logSubsystem is the tflog subsystem used for Pi-hole API traffic.
+	// It inherits its level from TF_LOG_PROVIDER.
+	logSubsystem = "pihole"
+
+	// maxLoggedBody bounds how much of a request or response body is logged
+	maxLoggedBody = 4 << 10
+)
+
+// secretFieldRegex matches JSON string fields holding credentials, including
+// values cut short by truncation.
+var secretFieldRegex = regexp.MustCompile(`("(?:password|app_password|pwhash|sid|csrf|totp)"\s*:\s*)"[^"]*"?`)
+
+// attemptsKey is the context key for the per-request attempt counter
+type attemptsKey struct{}
+
+// logContext returns ctx with the Pi-hole logging subsystem, masking the
+// client's credentials wherever they could appear.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem)
+
+	secrets := make([]string, 0, 3)
+	if c.password != "" {
+		secrets = append(secrets, c.password)
+	}
+	if c.basicAuthPassword != "" {
+		secrets = append(secrets, c.basicAuthPassword)
+	}
+	if sid := c.SessionID(); sid != "" {
+		secrets = append(secrets, sid)
+	}
+
+	if len(secrets) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, secrets...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, secrets...)
+	}
+
+	// Count the attempts made for this request, including retries
+	return context.WithValue(ctx, attemptsKey{}, new(int32))
+}
+
+// loggingTransport logs every request attempt and its response
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	if attempts, ok := ctx.Value(attemptsKey{}).(*int32); ok {
+		fields["attempt"] = atomic.AddInt32(attempts, 1)
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending Pi-hole API request", fields)
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			logged, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+			body.Close()
+			tflog.SubsystemTrace(ctx, logSubsystem, "Pi-hole API request body", mergeFields(fields, map[string]interface{}{
+				"body": redactBody(logged),
+			}))
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Pi-hole API request failed", mergeFields(fields, map[string]interface{}{
+			"error": err.Error(),
+		}))
+		return nil, err
+	}
+
+	fields["status"] = resp.StatusCode
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received Pi-hole API response", fields)
+
+	if resp.Body != nil && resp.Body != http.NoBody {
+		// Peek at the start of the body and hand the full body back to the caller
+		logged, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody+1))
+		resp.Body = &peekedBody{Reader: io.MultiReader(bytes.NewReader(logged), resp.Body), Closer: resp.Body}
+
+		tflog.SubsystemTrace(ctx, logSubsystem, "Pi-hole API response body", mergeFields(fields, map[string]interface{}{
+			"body": redactBody(logged),
+		}))
+	}
+
+	return resp, nil
+}
+
+// peekedBody is a response body that has been partly read for logging
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// redactBody masks credentials in a JSON body and truncates it for logging
+func redactBody(body []byte) string {
+	truncated := len(body) > maxLoggedBody
+	if truncated {
+		body = body[:maxLoggedBody]
+	}
+
+	redacted := secretFieldRegex.ReplaceAllString(string(body), `$1"***"`)
+	if truncated {
+		redacted += "...(truncated)"
+	}
+
+	return redacted
+}
+
+// mergeFields returns a copy of fields with extra added
+func mergeFields(fields, extra map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(fields)+len(extra))
+	for k, v := range fields {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
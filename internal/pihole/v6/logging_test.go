@@ -0,0 +1,87 @@
+package v6
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestRequestLoggingRedactsSecrets(t *testing.T) {
+	const password = "s3cret-password"
+
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.SetPassword(password)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c, err := NewClient(ctx, pihole.Config{BaseURL: srv.URL, Password: password})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.LocalDNS().List(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{password, c.SessionID()} {
+		if strings.Contains(logs, secret) {
+			t.Fatalf("log output contains a secret: %s", logs)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sawAuth, sawList bool
+	for _, e := range entries {
+		if e["@module"] != "provider."+logSubsystem || e["@message"] != "Received Pi-hole API response" {
+			continue
+		}
+		switch e["path"] {
+		case "/api/auth":
+			sawAuth = true
+		case dnsHostsPath:
+			sawList = e["status"] == float64(200) && e["attempt"] == float64(1) && e["method"] == "GET"
+		}
+	}
+
+	if !sawAuth || !sawList {
+		t.Fatalf("expected responses to be logged, got: %v", entries)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	cases := map[string]string{
+		`{"password":"hunter2"}`:                         `{"password":"***"}`,
+		`{"session":{"sid":"abc","csrf":"def"}}`:         `{"session":{"sid":"***","csrf":"***"}}`,
+		`{"totp": "123456", "domain":"foo.lan"}`:         `{"totp": "***", "domain":"foo.lan"}`,
+		`{"config":{"dns":{"hosts":["1.2.3.4 a.lan"]}}}`: `{"config":{"dns":{"hosts":["1.2.3.4 a.lan"]}}}`,
+	}
+
+	for in, expected := range cases {
+		if actual := redactBody([]byte(in)); actual != expected {
+			t.Errorf("redactBody(%s) = %s, expected %s", in, actual, expected)
+		}
+	}
+
+	long := []byte(`{"hosts":["` + strings.Repeat("x", maxLoggedBody) + `"]}`)
+	if actual := redactBody(long); len(actual) > maxLoggedBody+len("...(truncated)") || !strings.HasSuffix(actual, "...(truncated)") {
+		t.Errorf("expected a truncated body, got %d bytes", len(actual))
+	}
+
+	// A secret cut off by truncation must still be masked
+	cut := []byte(strings.Repeat(" ", maxLoggedBody-10) + `{"sid":"abcdefghijklmnop"}`)
+	if actual := redactBody(cut); strings.Contains(actual, "abc") {
+		t.Errorf("truncated secret leaked: %s", actual)
+	}
+}
@@ -0,0 +1,125 @@
+package v6
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+const (
+	networkDevicesPath = "/api/network/devices"
+
+	// maxNetworkDevices and maxNetworkAddresses raise the API's default
+	// limits of 999 devices and 25 addresses per device so that the whole
+	// table is returned.
+	maxNetworkDevices   = 100000
+	maxNetworkAddresses = 1000
+)
+
+type networkService struct {
+	client *Client
+	cache  listCache[pihole.NetworkDevice]
+}
+
+// networkDeviceAPIRecord represents a device in the Pi-hole v6 API response
+type networkDeviceAPIRecord struct {
+	ID         int    `json:"id"`
+	HWAddr     string `json:"hwaddr"`
+	Interface  string `json:"interface"`
+	FirstSeen  int64  `json:"firstSeen"`
+	LastQuery  int64  `json:"lastQuery"`
+	NumQueries int    `json:"numQueries"`
+	MACVendor  string `json:"macVendor"`
+	IPs        []struct {
+		IP          string `json:"ip"`
+		Name        string `json:"name"`
+		LastSeen    int64  `json:"lastSeen"`
+		NameUpdated int64  `json:"nameUpdated"`
+	} `json:"ips"`
+}
+
+// networkDevicesResponse is the API response for listing network devices
+type networkDevicesResponse struct {
+	Devices []networkDeviceAPIRecord `json:"devices"`
+}
+
+// toDevice converts an API record to a pihole.NetworkDevice
+func (r *networkDeviceAPIRecord) toDevice() pihole.NetworkDevice {
+	device := pihole.NetworkDevice{
+		ID:         r.ID,
+		HWAddr:     r.HWAddr,
+		Interface:  r.Interface,
+		Vendor:     r.MACVendor,
+		FirstSeen:  r.FirstSeen,
+		LastQuery:  r.LastQuery,
+		NumQueries: r.NumQueries,
+		Addresses:  make([]pihole.NetworkAddress, 0, len(r.IPs)),
+	}
+
+	for _, ip := range r.IPs {
+		device.Addresses = append(device.Addresses, pihole.NetworkAddress{
+			IP:          ip.IP,
+			Name:        ip.Name,
+			LastSeen:    ip.LastSeen,
+			NameUpdated: ip.NameUpdated,
+		})
+	}
+
+	return device
+}
+
+// List returns all devices in the network table.
+// The table is served from a per-client snapshot that is reloaded after
+// deletes and flushes.
+func (s *networkService) List(ctx context.Context) ([]pihole.NetworkDevice, error) {
+	return s.cache.get(ctx, s.fetch)
+}
+
+// fetch downloads the whole network table from the API
+func (s *networkService) fetch(ctx context.Context) ([]pihole.NetworkDevice, error) {
+	path := fmt.Sprintf("%s?max_devices=%d&max_addresses=%d", networkDevicesPath, maxNetworkDevices, maxNetworkAddresses)
+
+	resp, err := s.client.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result networkDevicesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	devices := make([]pihole.NetworkDevice, 0, len(result.Devices))
+	for _, d := range result.Devices {
+		devices = append(devices, d.toDevice())
+	}
+
+	return devices, nil
+}
+
+// Delete removes a device and its addresses from the network table.
+// Returns nil if the device doesn't exist (idempotent delete).
+func (s *networkService) Delete(ctx context.Context, id int) error {
+	defer s.cache.invalidate()
+
+	resp, err := s.client.delete(ctx, fmt.Sprintf("%s/%d", networkDevicesPath, id))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 204 = deleted, 404 = already gone (both are success)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package v6
+
+import (
+	"context"
+	"testing"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestNetworkDevices(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	keep := srv.AddDevice(fake.Device{
+		HWAddr:    "aa:bb:cc:dd:ee:01",
+		Interface: "eth0",
+		Vendor:    "Raspberry Pi Foundation",
+		LastQuery: 1700000000,
+		Addresses: []fake.Address{{IP: "192.168.1.10", Name: "pi.lan", LastSeen: 1700000100}},
+	})
+	stale := srv.AddDevice(fake.Device{HWAddr: "aa:bb:cc:dd:ee:02", Interface: "wlan0"})
+
+	c := newFakeClient(t, srv).Network()
+	ctx := context.Background()
+
+	devices, err := c.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("expected 2 devices, got %v", devices)
+	}
+
+	d := devices[0]
+	if d.ID != keep || d.Vendor != "Raspberry Pi Foundation" || len(d.Addresses) != 1 || d.Addresses[0].Name != "pi.lan" {
+		t.Errorf("unexpected device: %+v", d)
+	}
+	if d.LastSeen() != 1700000100 {
+		t.Errorf("expected last seen from the address, got %d", d.LastSeen())
+	}
+
+	// The table is read once per client until it changes
+	srv.AddDevice(fake.Device{HWAddr: "aa:bb:cc:dd:ee:03"})
+	if devices, err := c.List(ctx); err != nil || len(devices) != 2 {
+		t.Errorf("expected the cached table, got %v, %v", devices, err)
+	}
+
+	if err := c.Delete(ctx, stale); err != nil {
+		t.Fatal(err)
+	}
+	if devices, err := c.List(ctx); err != nil || len(devices) != 2 {
+		t.Errorf("expected the table to be reloaded after a delete, got %v, %v", devices, err)
+	}
+	if err := c.Delete(ctx, stale); err != nil {
+		t.Fatalf("deleting a missing device should succeed, got %v", err)
+	}
+	if n := len(srv.Devices()); n != 2 {
+		t.Errorf("expected 2 devices after delete, got %d", n)
+	}
+}
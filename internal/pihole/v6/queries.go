@@ -0,0 +1,128 @@
+package v6
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+const (
+	queriesPath = "/api/queries"
+
+	// queryPageSize is the number of queries fetched per request
+	queryPageSize = 100
+)
+
+type queryService struct {
+	client *Client
+}
+
+// queryAPIRecord represents a query in the Pi-hole v6 API response
+type queryAPIRecord struct {
+	ID     int     `json:"id"`
+	Time   float64 `json:"time"`
+	Type   string  `json:"type"`
+	Domain string  `json:"domain"`
+	CNAME  string  `json:"cname"`
+	Status string  `json:"status"`
+	Client struct {
+		IP   string `json:"ip"`
+		Name string `json:"name"`
+	} `json:"client"`
+	DNSSEC string `json:"dnssec"`
+	Reply  struct {
+		Type string  `json:"type"`
+		Time float64 `json:"time"`
+	} `json:"reply"`
+	Upstream string `json:"upstream"`
+}
+
+// queriesResponse is one page of the query log
+type queriesResponse struct {
+	Queries         []queryAPIRecord `json:"queries"`
+	Cursor          int              `json:"cursor"`
+	RecordsFiltered int              `json:"recordsFiltered"`
+}
+
+// toEntry converts an API record to a pihole.QueryLogEntry
+func (r *queryAPIRecord) toEntry() pihole.QueryLogEntry {
+	return pihole.QueryLogEntry{
+		ID:         r.ID,
+		Time:       r.Time,
+		Type:       r.Type,
+		Domain:     r.Domain,
+		CNAME:      r.CNAME,
+		Status:     r.Status,
+		ClientIP:   r.Client.IP,
+		ClientName: r.Client.Name,
+		Upstream:   r.Upstream,
+		DNSSEC:     r.DNSSEC,
+		ReplyType:  r.Reply.Type,
+		ReplyTime:  r.Reply.Time,
+	}
+}
+
+// Each calls fn for every query matching filter, newest first.
+// Only one page of the log is held in memory at a time.
+func (s *queryService) Each(ctx context.Context, filter pihole.QueryFilter, fn func(pihole.QueryLogEntry) bool) error {
+	params := queryParams(filter)
+	params.Set("length", strconv.Itoa(queryPageSize))
+
+	for start := 0; ; {
+		params.Set("start", strconv.Itoa(start))
+
+		var page queriesResponse
+		if err := s.client.getJSON(ctx, queriesPath+"?"+params.Encode(), &page); err != nil {
+			return err
+		}
+
+		// Pin later pages to the newest query of the first one, so queries
+		// arriving meanwhile don't shift the offsets
+		if !params.Has("cursor") && page.Cursor > 0 {
+			params.Set("cursor", strconv.Itoa(page.Cursor))
+		}
+
+		for _, q := range page.Queries {
+			if !fn(q.toEntry()) {
+				return nil
+			}
+		}
+
+		start += len(page.Queries)
+		if len(page.Queries) < queryPageSize || start >= page.RecordsFiltered {
+			return nil
+		}
+	}
+}
+
+// queryParams converts filter to the query log's request parameters
+func queryParams(filter pihole.QueryFilter) url.Values {
+	params := url.Values{}
+
+	if filter.From > 0 {
+		params.Set("from", strconv.FormatInt(filter.From, 10))
+	}
+	if filter.Until > 0 {
+		params.Set("until", strconv.FormatInt(filter.Until, 10))
+	}
+	if filter.Cursor > 0 {
+		params.Set("cursor", strconv.Itoa(filter.Cursor))
+	}
+
+	for key, value := range map[string]string{
+		"client_ip":   filter.ClientIP,
+		"client_name": filter.ClientName,
+		"domain":      filter.Domain,
+		"upstream":    filter.Upstream,
+		"type":        filter.Type,
+		"status":      filter.Status,
+	} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,96 @@
+package v6
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestQueriesPaging(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	for i := 0; i < 250; i++ {
+		status := "FORWARDED"
+		if i%5 == 0 {
+			status = "GRAVITY"
+		}
+		srv.AddQuery(fake.Query{Time: float64(1700000000 + i), Type: "A", Domain: fmt.Sprintf("host-%d.example.com", i), Status: status, ClientIP: "192.168.1.10"})
+	}
+
+	c := newFakeClient(t, srv).Queries()
+	ctx := context.Background()
+
+	var ids []int
+	err := c.Each(ctx, pihole.QueryFilter{}, func(q pihole.QueryLogEntry) bool {
+		ids = append(ids, q.ID)
+
+		// New queries arriving while paging must not shift later pages
+		if len(ids) == 1 {
+			srv.AddQuery(fake.Query{Time: 1800000000, Type: "A", Domain: "late.example.com"})
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 250 || ids[0] != 250 || ids[249] != 1 {
+		t.Fatalf("expected queries 250 to 1, got %d queries from %v", len(ids), ids[:min(3, len(ids))])
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] != ids[i-1]-1 {
+			t.Fatalf("queries skipped or repeated around index %d: %d after %d", i, ids[i], ids[i-1])
+		}
+	}
+
+	blocked := 0
+	err = c.Each(ctx, pihole.QueryFilter{Status: "GRAVITY", From: 1700000100}, func(q pihole.QueryLogEntry) bool {
+		if q.Status != "GRAVITY" || q.Time < 1700000100 {
+			t.Errorf("query outside the filter: %+v", q)
+		}
+		blocked++
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocked != 30 {
+		t.Errorf("expected 30 blocked queries, got %d", blocked)
+	}
+}
+
+func TestQueriesStopEarly(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	for i := 0; i < 250; i++ {
+		srv.AddQuery(fake.Query{Time: float64(1700000000 + i), Type: "A", Domain: "example.com"})
+	}
+
+	c := newFakeClient(t, srv).Queries()
+
+	seen := 0
+	err := c.Each(context.Background(), pihole.QueryFilter{}, func(pihole.QueryLogEntry) bool {
+		seen++
+		return seen < 5
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen != 5 {
+		t.Errorf("expected iteration to stop after 5 queries, got %d", seen)
+	}
+
+	pages := 0
+	for _, r := range srv.Requests() {
+		if r == "GET /api/queries" {
+			pages++
+		}
+	}
+	if pages != 1 {
+		t.Errorf("expected a single page to be fetched, got %d", pages)
+	}
+}
@@ -0,0 +1,70 @@
+package v6
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	// minPollInterval and maxPollInterval bound the delay between reads while
+	// waiting for a write to become visible.
+	minPollInterval = 25 * time.Millisecond
+	maxPollInterval = time.Second
+)
+
+// checkRetry is the retryablehttp retry policy. It retries connection errors,
+// 429 and 5xx responses like retryablehttp.DefaultRetryPolicy, except for
+// rejected logins: a 429 from /api/auth means all API seats are taken, which
+// will not change before the sessions expire.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests && strings.HasSuffix(resp.Request.URL.Path, "/api/auth") {
+		return false, nil
+	}
+
+	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+}
+
+// backoff returns the delay before the given retry (starting at 1) of a
+// service-level retry loop, following the client's retry policy.
+func (c *Client) backoff(retry int) time.Duration {
+	delay := c.retry.WaitMin << uint(retry-1)
+	if delay > c.retry.WaitMax || delay < c.retry.WaitMin {
+		delay = c.retry.WaitMax
+	}
+	return delay
+}
+
+// waitRetry sleeps before the given retry (starting at 1), returning early
+// with the context's error if it is done first.
+func (c *Client) waitRetry(ctx context.Context, retry int) error {
+	return sleep(ctx, c.backoff(retry))
+}
+
+// sleep waits for d, returning early with the context's error if it is done
+// first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pollInterval returns the delay before the given poll (starting at 1) of a
+// read-after-write check. It starts much shorter than the retry backoff, as
+// Pi-hole normally applies a change within a few hundred milliseconds.
+func pollInterval(poll int) time.Duration {
+	delay := minPollInterval << uint(poll-1)
+	if delay > maxPollInterval || delay < minPollInterval {
+		delay = maxPollInterval
+	}
+	return delay
+}
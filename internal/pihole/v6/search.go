@@ -0,0 +1,100 @@
+package v6
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+const searchPath = "/api/search"
+
+type searchService struct {
+	client *Client
+}
+
+// searchResponse is the API response for a domain search
+type searchResponse struct {
+	Search struct {
+		Domains []struct {
+			ID      int    `json:"id"`
+			Domain  string `json:"domain"`
+			Type    string `json:"type"`
+			Kind    string `json:"kind"`
+			Comment string `json:"comment"`
+			Enabled bool   `json:"enabled"`
+			Groups  []int  `json:"groups"`
+		} `json:"domains"`
+		Gravity []struct {
+			ID      int    `json:"id"`
+			Domain  string `json:"domain"`
+			Address string `json:"address"`
+			Type    string `json:"type"`
+			Comment string `json:"comment"`
+			Enabled bool   `json:"enabled"`
+			Groups  []int  `json:"groups"`
+		} `json:"gravity"`
+		Results struct {
+			Domains struct {
+				Exact int `json:"exact"`
+				Regex int `json:"regex"`
+			} `json:"domains"`
+			Gravity struct {
+				Allow int `json:"allow"`
+				Block int `json:"block"`
+			} `json:"gravity"`
+		} `json:"results"`
+	} `json:"search"`
+}
+
+// Domain returns the allow/deny entries and gravity lists matching domain
+func (s *searchService) Domain(ctx context.Context, domain string, opts pihole.SearchOptions) (*pihole.DomainSearchResult, error) {
+	params := url.Values{}
+	params.Set("partial", strconv.FormatBool(opts.Partial))
+	if opts.Limit > 0 {
+		params.Set("N", strconv.Itoa(opts.Limit))
+	}
+
+	var result searchResponse
+	if err := s.client.getJSON(ctx, fmt.Sprintf("%s/%s?%s", searchPath, url.PathEscape(domain), params.Encode()), &result); err != nil {
+		return nil, err
+	}
+
+	search := result.Search
+	r := &pihole.DomainSearchResult{
+		Domains:             make([]pihole.DomainMatch, 0, len(search.Domains)),
+		Gravity:             make([]pihole.GravityMatch, 0, len(search.Gravity)),
+		ExactMatches:        search.Results.Domains.Exact,
+		RegexMatches:        search.Results.Domains.Regex,
+		GravityAllowMatches: search.Results.Gravity.Allow,
+		GravityBlockMatches: search.Results.Gravity.Block,
+	}
+
+	for _, d := range search.Domains {
+		r.Domains = append(r.Domains, pihole.DomainMatch{
+			ID:      d.ID,
+			Domain:  d.Domain,
+			Type:    d.Type,
+			Kind:    d.Kind,
+			Comment: d.Comment,
+			Enabled: d.Enabled,
+			Groups:  d.Groups,
+		})
+	}
+
+	for _, g := range search.Gravity {
+		r.Gravity = append(r.Gravity, pihole.GravityMatch{
+			ID:      g.ID,
+			Domain:  g.Domain,
+			Address: g.Address,
+			Type:    g.Type,
+			Comment: g.Comment,
+			Enabled: g.Enabled,
+			Groups:  g.Groups,
+		})
+	}
+
+	return r, nil
+}
@@ -0,0 +1,90 @@
+package v6
+
+import (
+	"context"
+	"testing"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestSearchDomain(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.AddDomainEntry(fake.DomainEntry{Domain: "ads.example.com", Type: "deny", Kind: "exact", Enabled: true, Groups: []int{0}})
+	srv.AddDomainEntry(fake.DomainEntry{Domain: `(^|\.)tracker\.`, Type: "deny", Kind: "regex", Enabled: true, Groups: []int{0, 2}})
+	srv.AddGravityEntry(fake.GravityEntry{Domain: "ads.example.com", Address: "https://lists.example.com/ads.txt", Type: "block", Enabled: true, Groups: []int{0}})
+	srv.AddGravityEntry(fake.GravityEntry{Domain: "tracker.example.com", Address: "https://lists.example.com/ads.txt", Type: "block", Enabled: true})
+
+	c := newFakeClient(t, srv).Search()
+	ctx := context.Background()
+
+	result, err := c.Domain(ctx, "ads.example.com", pihole.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ExactMatches != 1 || result.RegexMatches != 0 || result.GravityBlockMatches != 1 {
+		t.Errorf("unexpected counts: %+v", result)
+	}
+	if len(result.Gravity) != 1 || result.Gravity[0].Address != "https://lists.example.com/ads.txt" {
+		t.Errorf("unexpected gravity matches: %+v", result.Gravity)
+	}
+	if !result.Blocked("ads.example.com") {
+		t.Error("expected ads.example.com to be blocked")
+	}
+
+	result, err = c.Domain(ctx, "tracker.example.com", pihole.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RegexMatches != 1 || len(result.Domains) != 1 || len(result.Domains[0].Groups) != 2 {
+		t.Errorf("expected the regex to match with its groups, got %+v", result.Domains)
+	}
+
+	result, err = c.Domain(ctx, "example.com", pihole.SearchOptions{Partial: true, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.GravityBlockMatches != 2 || len(result.Gravity) != 1 {
+		t.Errorf("expected 2 partial gravity matches limited to 1 entry, got %d and %d", result.GravityBlockMatches, len(result.Gravity))
+	}
+	if result.Blocked("example.com") {
+		t.Error("matches for other domains must not block example.com")
+	}
+}
+
+func TestSearchResultBlocked(t *testing.T) {
+	exact := func(typ string) pihole.DomainMatch {
+		return pihole.DomainMatch{Domain: "example.com", Type: typ, Kind: "exact", Enabled: true}
+	}
+	regex := func(typ string) pihole.DomainMatch {
+		return pihole.DomainMatch{Domain: "example", Type: typ, Kind: "regex", Enabled: true}
+	}
+	gravity := func(typ string) pihole.GravityMatch {
+		return pihole.GravityMatch{Domain: "example.com", Type: typ, Enabled: true}
+	}
+
+	tests := []struct {
+		name    string
+		result  pihole.DomainSearchResult
+		blocked bool
+	}{
+		{name: "no matches", result: pihole.DomainSearchResult{}, blocked: false},
+		{name: "gravity block", result: pihole.DomainSearchResult{Gravity: []pihole.GravityMatch{gravity("block")}}, blocked: true},
+		{name: "gravity allow wins", result: pihole.DomainSearchResult{Gravity: []pihole.GravityMatch{gravity("block"), gravity("allow")}}, blocked: false},
+		{name: "regex deny beats gravity allow", result: pihole.DomainSearchResult{Domains: []pihole.DomainMatch{regex("deny")}, Gravity: []pihole.GravityMatch{gravity("allow")}}, blocked: true},
+		{name: "regex allow beats regex deny", result: pihole.DomainSearchResult{Domains: []pihole.DomainMatch{regex("deny"), regex("allow")}}, blocked: false},
+		{name: "exact deny beats regex allow", result: pihole.DomainSearchResult{Domains: []pihole.DomainMatch{regex("allow"), exact("deny")}}, blocked: true},
+		{name: "exact allow wins", result: pihole.DomainSearchResult{Domains: []pihole.DomainMatch{exact("deny"), exact("allow")}}, blocked: false},
+		{name: "disabled ignored", result: pihole.DomainSearchResult{Domains: []pihole.DomainMatch{{Domain: "example.com", Type: "deny", Kind: "exact"}}}, blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.Blocked("example.com"); got != tt.blocked {
+				t.Errorf("got %v, want %v", got, tt.blocked)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package v6
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+const (
+	sessionsPath = "/api/auth/sessions"
+	sessionPath  = "/api/auth/session"
+)
+
+type sessionService struct {
+	client *Client
+}
+
+// sessionAPIRecord represents a session in the Pi-hole v6 API response
+type sessionAPIRecord struct {
+	ID             int    `json:"id"`
+	CurrentSession bool   `json:"current_session"`
+	Valid          bool   `json:"valid"`
+	LoginAt        int64  `json:"login_at"`
+	LastActive     int64  `json:"last_active"`
+	ValidUntil     int64  `json:"valid_until"`
+	RemoteAddr     string `json:"remote_addr"`
+	UserAgent      string `json:"user_agent"`
+}
+
+// sessionsResponse is the API response for listing sessions
+type sessionsResponse struct {
+	Sessions []sessionAPIRecord `json:"sessions"`
+}
+
+// List returns all active API sessions
+func (s *sessionService) List(ctx context.Context) ([]pihole.Session, error) {
+	resp, err := s.client.get(ctx, sessionsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result sessionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	sessions := make([]pihole.Session, 0, len(result.Sessions))
+	for _, r := range result.Sessions {
+		sessions = append(sessions, pihole.Session{
+			ID:         r.ID,
+			Current:    r.CurrentSession,
+			Valid:      r.Valid,
+			LoginAt:    r.LoginAt,
+			LastActive: r.LastActive,
+			ValidUntil: r.ValidUntil,
+			RemoteAddr: r.RemoteAddr,
+			UserAgent:  r.UserAgent,
+		})
+	}
+
+	return sessions, nil
+}
+
+// Delete revokes a session by the ID reported by List.
+// Returns nil if the session doesn't exist (idempotent delete).
+func (s *sessionService) Delete(ctx context.Context, id int) error {
+	resp, err := s.client.delete(ctx, fmt.Sprintf("%s/%d", sessionPath, id))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// 204 = revoked, 404 = already gone (both are success)
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// reapStaleSessions revokes the sessions other than the current one that
+// were created by the same program and have been idle for staleSessionAge.
+// Only a failure to list the sessions is returned; failures to revoke one
+// are logged, as the client works regardless.
+func (c *Client) reapStaleSessions(ctx context.Context) error {
+	// Sessions without a user agent of their own cannot be told apart
+	if c.userAgent == "" && c.reapUserAgent == "" {
+		return nil
+	}
+
+	logCtx := c.logContext(ctx)
+
+	sessions, err := c.sessions.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-c.staleSessionAge).Unix()
+	for _, session := range sessions {
+		if session.Current || !c.reapable(session.UserAgent) || session.LastActive > cutoff {
+			continue
+		}
+
+		if err := c.sessions.Delete(ctx, session.ID); err != nil {
+			tflog.SubsystemWarn(logCtx, logSubsystem, "Failed to revoke stale session", map[string]interface{}{
+				"session": session.ID,
+				"error":   err.Error(),
+			})
+			continue
+		}
+
+		tflog.SubsystemInfo(logCtx, logSubsystem, "Revoked stale session", map[string]interface{}{
+			"session":     session.ID,
+			"last_active": time.Unix(session.LastActive, 0).UTC().Format(time.RFC3339),
+		})
+		c.revoked = append(c.revoked, session)
+	}
+
+	return nil
+}
+
+// reapable reports whether a session with the given user agent was created
+// by the same program as the client, whatever its version
+func (c *Client) reapable(userAgent string) bool {
+	if c.reapUserAgent != "" {
+		return strings.Contains(userAgent, c.reapUserAgent)
+	}
+	return userAgent == c.userAgent
+}
+
+// recoverSeats reaps stale sessions after a login was rejected for lack of
+// API seats, and reports whether any were revoked. Pi-hole only lists
+// sessions to a logged in client, so a session that an earlier run left in
+// the journal is borrowed to do so. Borrowed sessions that are no longer
+// valid are dropped from the journal.
+func (c *Client) recoverSeats(ctx context.Context) bool {
+	if c.journal == nil {
+		return false
+	}
+
+	logCtx := c.logContext(ctx)
+
+	sids, err := c.journal.sessions()
+	if err != nil {
+		tflog.SubsystemWarn(logCtx, logSubsystem, "Failed to read the session journal", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return false
+	}
+
+	// A borrowed session is not the client's own to replace when rejected
+	c.externalSession = true
+	defer func() {
+		c.externalSession = false
+		c.sessionLock.Lock()
+		c.sessionID = ""
+		c.sessionLock.Unlock()
+	}()
+
+	revoked := len(c.revoked)
+	for _, sid := range sids {
+		c.sessionLock.Lock()
+		c.sessionID = sid
+		c.sessionLock.Unlock()
+
+		err := c.reapStaleSessions(ctx)
+		if errors.Is(err, pihole.ErrUnauthorized) {
+			c.journalSession(ctx, sid, "")
+			continue
+		}
+		if err != nil {
+			tflog.SubsystemWarn(logCtx, logSubsystem, "Failed to list sessions to reap", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		break
+	}
+
+	return len(c.revoked) > revoked
+}
+
+// journalSession records the session sid in the journal, if there is one,
+// and forgets the session it replaces. Failures are only logged, as the
+// journal only matters to later runs.
+func (c *Client) journalSession(ctx context.Context, replaced, sid string) {
+	if c.journal == nil {
+		return
+	}
+
+	if replaced != "" {
+		if err := c.journal.remove(replaced); err != nil {
+			tflog.SubsystemWarn(c.logContext(ctx), logSubsystem, "Failed to remove a session from the journal", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+	if sid != "" {
+		if err := c.journal.add(sid); err != nil {
+			tflog.SubsystemWarn(c.logContext(ctx), logSubsystem, "Failed to record the session in the journal", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+}
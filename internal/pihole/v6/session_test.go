@@ -0,0 +1,217 @@
+package v6
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestSessions(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.AddSession("curl/8.0")
+
+	c := newFakeClient(t, srv).Sessions()
+	ctx := context.Background()
+
+	sessions, err := c.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %v", sessions)
+	}
+
+	other, current := sessions[0], sessions[1]
+	if other.UserAgent != "curl/8.0" || other.Current {
+		t.Errorf("unexpected session: %+v", other)
+	}
+	if current.UserAgent != "terraform-provider-pihole/test" || !current.Current || !current.Valid {
+		t.Errorf("unexpected current session: %+v", current)
+	}
+
+	if err := c.Delete(ctx, other.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(ctx, other.ID); err != nil {
+		t.Fatalf("deleting a missing session should succeed, got %v", err)
+	}
+	if n := len(srv.Sessions()); n != 1 {
+		t.Errorf("expected 1 session after delete, got %d", n)
+	}
+}
+
+func TestReapStaleSessions(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	const userAgent = "terraform-provider-pihole/test"
+
+	// Two runs crashed an hour ago, one run is in progress, another has been
+	// waiting for ten minutes and another tool has been idle for an hour
+	crashed := []string{srv.AddSession(userAgent), srv.AddSession(userAgent)}
+	for _, sid := range crashed {
+		srv.AgeSession(sid, time.Hour)
+	}
+	running := srv.AddSession(userAgent)
+	waiting := srv.AddSession(userAgent)
+	srv.AgeSession(waiting, 10*time.Minute)
+	other := srv.AddSession("curl/8.0")
+	srv.AgeSession(other, time.Hour)
+	srv.SetMaxSessions(6)
+
+	cfg := pihole.Config{BaseURL: srv.URL, Password: fake.DefaultPassword, UserAgent: userAgent}
+
+	// Sessions are only reaped when enabled
+	c, err := NewClient(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked := c.RevokedSessions(); len(revoked) != 0 {
+		t.Errorf("expected no sessions to be revoked, got %v", revoked)
+	}
+	if err := c.Logout(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// nor by a client reusing a session that was passed in
+	cfg.ReapStaleSessions = true
+	external, err := NewClient(context.Background(), pihole.Config{
+		BaseURL:           srv.URL,
+		UserAgent:         userAgent,
+		SessionID:         running,
+		ReapStaleSessions: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked := external.RevokedSessions(); len(revoked) != 0 {
+		t.Errorf("expected no sessions to be revoked, got %v", revoked)
+	}
+
+	c, err = NewClient(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revoked := c.RevokedSessions()
+	if len(revoked) != len(crashed) {
+		t.Fatalf("expected the sessions of crashed runs to be revoked, got %v", revoked)
+	}
+	for _, session := range revoked {
+		if session.UserAgent != userAgent || session.Current {
+			t.Errorf("unexpected revoked session: %+v", session)
+		}
+	}
+
+	remaining := make(map[string]bool)
+	for _, session := range srv.Sessions() {
+		remaining[session.ID] = true
+	}
+	expected := []string{running, waiting, other, c.SessionID()}
+	if len(remaining) != len(expected) {
+		t.Errorf("expected %d sessions to remain, got %d", len(expected), len(remaining))
+	}
+	for _, sid := range expected {
+		if !remaining[sid] {
+			t.Errorf("expected session %s to remain", sid)
+		}
+	}
+
+	// A shorter age reaps the waiting run too
+	cfg.StaleSessionAge = 5 * time.Minute
+	c, err = NewClient(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked := c.RevokedSessions(); len(revoked) != 1 {
+		t.Errorf("expected the waiting run's session to be revoked, got %v", revoked)
+	}
+	for _, session := range srv.Sessions() {
+		if session.ID == waiting {
+			t.Errorf("expected session %s to be revoked", waiting)
+		}
+	}
+}
+
+func TestRecoverSeats(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	srv.SetMaxSessions(2)
+
+	journal := t.TempDir()
+	cfg := pihole.Config{
+		BaseURL:           srv.URL,
+		Password:          fake.DefaultPassword,
+		UserAgent:         "Terraform/1.9.0 terraform-provider-pihole/1.2.0",
+		ReapStaleSessions: true,
+		ReapUserAgent:     "terraform-provider-pihole/",
+		SessionJournal:    journal,
+	}
+
+	// A run on this machine crashed an hour ago, and so did a run of another
+	// version elsewhere, taking both seats
+	crashed, err := NewClient(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.AgeSession(crashed.SessionID(), time.Hour)
+	elsewhere := srv.AddSession("Terraform/1.5.7 terraform-provider-pihole/1.0.0")
+	srv.AgeSession(elsewhere, time.Hour)
+
+	// Without a session in the journal the login cannot recover, and sessions
+	// that have expired since are dropped from it
+	noJournal := cfg
+	noJournal.SessionJournal = t.TempDir()
+	expired := newSessionJournal(noJournal.SessionJournal, crashed.baseURL)
+	if err := expired.add("expired"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewClient(context.Background(), noJournal); !errors.Is(err, pihole.ErrSeatsExceeded) {
+		t.Fatalf("expected ErrSeatsExceeded, got %v", err)
+	}
+	if sids, _ := expired.sessions(); len(sids) != 0 {
+		t.Errorf("expected the expired session to be dropped, got %v", sids)
+	}
+
+	c, err := NewClient(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("expected the login to recover, got %v", err)
+	}
+
+	revoked := c.RevokedSessions()
+	if len(revoked) != 1 || !strings.Contains(revoked[0].UserAgent, "terraform-provider-pihole/1.0.0") {
+		t.Errorf("expected the session of the other version to be revoked, got %v", revoked)
+	}
+	for _, session := range srv.Sessions() {
+		if session.ID == elsewhere {
+			t.Errorf("expected session %s to be revoked", elsewhere)
+		}
+	}
+
+	sids, err := c.journal.sessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(sids)
+	expected := []string{crashed.SessionID(), c.SessionID()}
+	slices.Sort(expected)
+	if !slices.Equal(sids, expected) {
+		t.Errorf("expected the journal to hold %v, got %v", expected, sids)
+	}
+
+	// Logging out removes the session from the journal
+	if err := c.Logout(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if sids, _ := c.journal.sessions(); len(sids) != 1 || sids[0] != crashed.SessionID() {
+		t.Errorf("expected only the crashed run's session in the journal, got %v", sids)
+	}
+}
@@ -0,0 +1,143 @@
+package v6
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+const statsPath = "/api/stats"
+
+type statsService struct {
+	client *Client
+}
+
+// statsSummaryResponse is the API response for the statistics summary
+type statsSummaryResponse struct {
+	Queries struct {
+		Total          int            `json:"total"`
+		Blocked        int            `json:"blocked"`
+		PercentBlocked float64        `json:"percent_blocked"`
+		UniqueDomains  int            `json:"unique_domains"`
+		Forwarded      int            `json:"forwarded"`
+		Cached         int            `json:"cached"`
+		Frequency      float64        `json:"frequency"`
+		Types          map[string]int `json:"types"`
+	} `json:"queries"`
+	Clients struct {
+		Active int `json:"active"`
+		Total  int `json:"total"`
+	} `json:"clients"`
+	Gravity struct {
+		DomainsBeingBlocked int   `json:"domains_being_blocked"`
+		LastUpdate          int64 `json:"last_update"`
+	} `json:"gravity"`
+}
+
+// topDomainsResponse is the API response for the top domains
+type topDomainsResponse struct {
+	Domains []struct {
+		Domain string `json:"domain"`
+		Count  int    `json:"count"`
+	} `json:"domains"`
+}
+
+// topClientsResponse is the API response for the top clients
+type topClientsResponse struct {
+	Clients []struct {
+		IP    string `json:"ip"`
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	} `json:"clients"`
+}
+
+// upstreamsResponse is the API response for the upstream destinations
+type upstreamsResponse struct {
+	Upstreams []struct {
+		IP         string `json:"ip"`
+		Name       string `json:"name"`
+		Port       int    `json:"port"`
+		Count      int    `json:"count"`
+		Statistics struct {
+			Response float64 `json:"response"`
+			Variance float64 `json:"variance"`
+		} `json:"statistics"`
+	} `json:"upstreams"`
+}
+
+// Summary returns the overview of answered queries
+func (s *statsService) Summary(ctx context.Context) (*pihole.StatsSummary, error) {
+	var result statsSummaryResponse
+	if err := s.client.getJSON(ctx, statsPath+"/summary", &result); err != nil {
+		return nil, err
+	}
+
+	return &pihole.StatsSummary{
+		TotalQueries:        result.Queries.Total,
+		BlockedQueries:      result.Queries.Blocked,
+		PercentBlocked:      result.Queries.PercentBlocked,
+		UniqueDomains:       result.Queries.UniqueDomains,
+		ForwardedQueries:    result.Queries.Forwarded,
+		CachedQueries:       result.Queries.Cached,
+		QueryFrequency:      result.Queries.Frequency,
+		QueryTypes:          result.Queries.Types,
+		ActiveClients:       result.Clients.Active,
+		TotalClients:        result.Clients.Total,
+		DomainsBeingBlocked: result.Gravity.DomainsBeingBlocked,
+		GravityLastUpdate:   result.Gravity.LastUpdate,
+	}, nil
+}
+
+// TopDomains returns the count most queried domains, either blocked or permitted
+func (s *statsService) TopDomains(ctx context.Context, blocked bool, count int) ([]pihole.TopDomain, error) {
+	var result topDomainsResponse
+	if err := s.client.getJSON(ctx, fmt.Sprintf("%s/top_domains?blocked=%t&count=%d", statsPath, blocked, count), &result); err != nil {
+		return nil, err
+	}
+
+	domains := make([]pihole.TopDomain, 0, len(result.Domains))
+	for _, d := range result.Domains {
+		domains = append(domains, pihole.TopDomain{Domain: d.Domain, Count: d.Count})
+	}
+
+	return domains, nil
+}
+
+// TopClients returns the count clients sending the most queries, counting
+// either blocked or all queries
+func (s *statsService) TopClients(ctx context.Context, blocked bool, count int) ([]pihole.TopClient, error) {
+	var result topClientsResponse
+	if err := s.client.getJSON(ctx, fmt.Sprintf("%s/top_clients?blocked=%t&count=%d", statsPath, blocked, count), &result); err != nil {
+		return nil, err
+	}
+
+	clients := make([]pihole.TopClient, 0, len(result.Clients))
+	for _, c := range result.Clients {
+		clients = append(clients, pihole.TopClient{IP: c.IP, Name: c.Name, Count: c.Count})
+	}
+
+	return clients, nil
+}
+
+// Upstreams returns the destinations queries were answered from
+func (s *statsService) Upstreams(ctx context.Context) ([]pihole.Upstream, error) {
+	var result upstreamsResponse
+	if err := s.client.getJSON(ctx, statsPath+"/upstreams", &result); err != nil {
+		return nil, err
+	}
+
+	upstreams := make([]pihole.Upstream, 0, len(result.Upstreams))
+	for _, u := range result.Upstreams {
+		upstreams = append(upstreams, pihole.Upstream{
+			IP:               u.IP,
+			Name:             u.Name,
+			Port:             u.Port,
+			Count:            u.Count,
+			ResponseTime:     u.Statistics.Response,
+			ResponseVariance: u.Statistics.Variance,
+		})
+	}
+
+	return upstreams, nil
+}
@@ -0,0 +1,66 @@
+package v6
+
+import (
+	"context"
+	"testing"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestStats(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	srv.SetStats(fake.Stats{
+		TotalQueries:   200,
+		BlockedQueries: 50,
+		QueryTypes:     map[string]int{"A": 150, "AAAA": 50},
+		TopDomains:     []fake.DomainCount{{Domain: "example.com", Count: 30}, {Domain: "example.org", Count: 20}},
+		TopBlocked:     []fake.DomainCount{{Domain: "ads.example.com", Count: 40}},
+		TopClients:     []fake.ClientCount{{IP: "192.168.1.10", Name: "laptop", Count: 120}},
+		Upstreams:      []fake.Upstream{{IP: "1.1.1.1", Name: "one.one.one.one", Port: 53, Count: 100, Response: 12.5}},
+	})
+
+	c := newFakeClient(t, srv).Stats()
+	ctx := context.Background()
+
+	summary, err := c.Summary(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.TotalQueries != 200 || summary.PercentBlocked != 25 || summary.QueryTypes["AAAA"] != 50 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	domains, err := c.TopDomains(ctx, false, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(domains) != 1 || domains[0].Domain != "example.com" {
+		t.Errorf("unexpected top domains: %v", domains)
+	}
+
+	blocked, err := c.TopDomains(ctx, true, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocked) != 1 || blocked[0].Domain != "ads.example.com" {
+		t.Errorf("unexpected top blocked domains: %v", blocked)
+	}
+
+	clients, err := c.TopClients(ctx, false, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clients) != 1 || clients[0].Name != "laptop" || clients[0].Count != 120 {
+		t.Errorf("unexpected top clients: %v", clients)
+	}
+
+	upstreams, err := c.Upstreams(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(upstreams) != 1 || upstreams[0].Port != 53 || upstreams[0].ResponseTime != 12.5 {
+		t.Errorf("unexpected upstreams: %+v", upstreams)
+	}
+}
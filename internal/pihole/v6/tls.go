@@ -0,0 +1,90 @@
+package v6
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// newTLSConfig returns the TLS configuration for cfg, or nil if cfg leaves
+// the defaults in place
+func newTLSConfig(cfg pihole.Config) (*tls.Config, error) {
+	if cfg.CAFile == "" && cfg.CAPEM == "" && cfg.ClientCertFile == "" && cfg.ClientCertPEM == "" &&
+		cfg.ClientKeyFile == "" && cfg.ClientKeyPEM == "" && cfg.TLSServerName == "" && !cfg.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: cfg.TLSServerName,
+		// For self-signed certificates without a CA
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	// Custom CAs replace the system roots
+	if cfg.CAFile != "" || cfg.CAPEM != "" {
+		rootCAs := x509.NewCertPool()
+
+		if cfg.CAFile != "" {
+			ca, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file %q: %w", cfg.CAFile, err)
+			}
+			if !rootCAs.AppendCertsFromPEM(ca) {
+				return nil, fmt.Errorf("failed to parse CA certificates from %q", cfg.CAFile)
+			}
+		}
+
+		if cfg.CAPEM != "" && !rootCAs.AppendCertsFromPEM([]byte(cfg.CAPEM)) {
+			return nil, errors.New("failed to parse CA certificates from the inline PEM")
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	certPEM, err := pemMaterial("client certificate", cfg.ClientCertFile, cfg.ClientCertPEM)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := pemMaterial("client key", cfg.ClientKeyFile, cfg.ClientKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case certPEM != nil && keyPEM != nil:
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case certPEM != nil:
+		return nil, errors.New("a client certificate requires a client key")
+	case keyPEM != nil:
+		return nil, errors.New("a client key requires a client certificate")
+	}
+
+	return tlsConfig, nil
+}
+
+// pemMaterial returns the PEM encoded what from file or inline, at most one
+// of which may be set. It returns nil if neither is.
+func pemMaterial(what, file, inline string) ([]byte, error) {
+	switch {
+	case file != "" && inline != "":
+		return nil, fmt.Errorf("the %s can be set from a file or inline, not both", what)
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s file %q: %w", what, file, err)
+		}
+		return data, nil
+	case inline != "":
+		return []byte(inline), nil
+	default:
+		return nil, nil
+	}
+}
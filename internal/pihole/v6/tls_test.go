@@ -0,0 +1,217 @@
+package v6
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+// testCert is a certificate and its key, PEM encoded
+type testCert struct {
+	cert, key []byte
+	parsed    *x509.Certificate
+	signer    *ecdsa.PrivateKey
+}
+
+// newTestCert issues a certificate from parent, or a self-signed CA if
+// parent is nil
+func newTestCert(t *testing.T, parent *testCert, template *x509.Certificate) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	issuer, signer := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		issuer, signer = parent.parsed, parent.signer
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCert{
+		cert:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		parsed: parsed,
+		signer: key,
+	}
+}
+
+// newMTLSServer serves srv over TLS with a certificate for pihole.test,
+// requiring client certificates issued by ca
+func newMTLSServer(t *testing.T, srv *fake.Server, ca *testCert) *httptest.Server {
+	t.Helper()
+
+	serverCert := newTestCert(t, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "pihole.test"},
+		DNSNames:    []string{"pihole.test"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	pair, err := tls.X509KeyPair(serverCert.cert, serverCert.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.parsed)
+
+	target, _ := url.Parse(srv.URL)
+	front := httptest.NewUnstartedServer(httputil.NewSingleHostReverseProxy(target))
+	front.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	front.StartTLS()
+	t.Cleanup(front.Close)
+
+	return front
+}
+
+func TestMutualTLS(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+
+	ca := newTestCert(t, nil, &x509.Certificate{Subject: pkix.Name{CommonName: "Test CA"}})
+	client := newTestCert(t, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "terraform"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	front := newMTLSServer(t, srv, ca)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	if err := os.WriteFile(certFile, client.cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := pihole.Config{
+		BaseURL:        front.URL,
+		Password:       fake.DefaultPassword,
+		CAPEM:          string(ca.cert),
+		ClientCertFile: certFile,
+		ClientKeyPEM:   string(client.key),
+		TLSServerName:  "pihole.test",
+	}
+
+	c, err := NewClient(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.LocalDNS().List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the server name the certificate does not match 127.0.0.1
+	noServerName := cfg
+	noServerName.TLSServerName = ""
+	noServerName.Retry = &pihole.RetryPolicy{}
+	if _, err := NewClient(context.Background(), noServerName); err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("expected a certificate error without the server name, got %v", err)
+	}
+
+	// The gateway rejects connections without a client certificate
+	noClientCert := cfg
+	noClientCert.ClientCertFile = ""
+	noClientCert.ClientKeyPEM = ""
+	noClientCert.Retry = &pihole.RetryPolicy{}
+	if _, err := NewClient(context.Background(), noClientCert); err == nil {
+		t.Error("expected an error without a client certificate")
+	}
+}
+
+func TestTLSTransport(t *testing.T) {
+	ca := newTestCert(t, nil, &x509.Certificate{Subject: pkix.Name{CommonName: "Test CA"}})
+
+	c, err := NewClient(context.Background(), pihole.Config{
+		BaseURL:   "https://pi.hole",
+		CAPEM:     string(ca.cert),
+		SessionID: "reused",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Custom TLS settings must not bypass retries, logging or the proxy
+	retrying, ok := c.http.Transport.(*retryablehttp.RoundTripper)
+	if !ok {
+		t.Fatalf("expected requests to be retried, got transport %T", c.http.Transport)
+	}
+	logging, ok := retrying.Client.HTTPClient.Transport.(*loggingTransport)
+	if !ok {
+		t.Fatalf("expected requests to be logged, got transport %T", retrying.Client.HTTPClient.Transport)
+	}
+	transport, ok := logging.next.(*http.Transport)
+	if !ok {
+		t.Fatalf("unexpected transport %T", logging.next)
+	}
+	if transport.Proxy == nil || transport.DisableKeepAlives || transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil {
+		t.Errorf("expected a pooled transport with the proxy from the environment and the CA, got %+v", transport)
+	}
+}
+
+func TestTLSConfigErrors(t *testing.T) {
+	ca := newTestCert(t, nil, &x509.Certificate{Subject: pkix.Name{CommonName: "Test CA"}})
+
+	cases := []struct {
+		name     string
+		cfg      pihole.Config
+		expected string
+	}{
+		{"invalid CA", pihole.Config{CAPEM: "not a certificate"}, "failed to parse CA certificates"},
+		{"missing CA file", pihole.Config{CAFile: "/nonexistent/ca.crt"}, "failed to read CA file"},
+		{"certificate without key", pihole.Config{ClientCertPEM: string(ca.cert)}, "requires a client key"},
+		{"key without certificate", pihole.Config{ClientKeyPEM: string(ca.key)}, "requires a client certificate"},
+		{"file and inline", pihole.Config{ClientCertFile: "client.crt", ClientCertPEM: string(ca.cert)}, "not both"},
+		{"mismatched key", pihole.Config{ClientCertPEM: string(ca.cert), ClientKeyPEM: string(newTestCert(t, nil, &x509.Certificate{}).key)}, "failed to load client certificate"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.BaseURL = "https://pi.hole"
+			_, err := NewClient(context.Background(), tc.cfg)
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("expected an error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -1,37 +1,111 @@
 package provider
 
 import (
+	"errors"
 	"sync"
+	"time"
 
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
+// defaultResourceTimeout bounds resource operations unless overridden by a
+// timeouts block. Each API request is additionally bounded by request_timeout.
+const defaultResourceTimeout = 5 * time.Minute
+
 // ProviderMeta holds the Pi-hole client and coordination primitives shared
 // across all resource operations within a Terraform run.
 type ProviderMeta struct {
 	// Client is the Pi-hole API client
 	Client pihole.Client
 
-	// mu serializes ALL Pi-hole API operations to prevent race conditions.
-	// The Pi-hole API can fail silently when operations occur concurrently,
-	// so we use a single mutex to ensure operations happen sequentially.
-	// This also ensures ForceNew replacement operations (delete + create)
-	// are atomic - no other operation can interleave between the delete
-	// and create of the same resource.
-	mu sync.Mutex
+	// mu coordinates Pi-hole API operations using a readers/writer scheme.
+	// The Pi-hole API can fail silently when writes occur concurrently, so
+	// writes other than those of DNS and CNAME records (see records) take the
+	// lock exclusively and happen sequentially. This also
+	// ensures ForceNew replacement operations (delete + create) are atomic -
+	// no other operation can interleave between the delete and create of the
+	// same resource. Reads only take a shared lock and proceed in parallel;
+	// they are served from the client's per-run snapshot of each list, which
+	// writes invalidate.
+	mu sync.RWMutex
+
+	// records orders writes to individual DNS and CNAME records. Those writes
+	// are coalesced into single config updates by the client, so instead of
+	// the exclusive lock they hold the shared lock plus the lock for their
+	// record, keeping a ForceNew delete + create of the same record ordered.
+	records   map[string]*recordLock
+	recordsMu sync.Mutex
+}
+
+// recordLock is a reference-counted mutex for a single record key
+type recordLock struct {
+	mu   sync.Mutex
+	refs int
 }
 
-// Lock acquires the global operation mutex. Call this before any Pi-hole API operation.
+// Lock acquires the operation lock exclusively. Call this before any Pi-hole API write.
 func (p *ProviderMeta) Lock() {
 	p.mu.Lock()
 }
 
-// Unlock releases the global operation mutex. Always defer this after Lock().
+// Unlock releases the exclusive operation lock. Always defer this after Lock().
 func (p *ProviderMeta) Unlock() {
 	p.mu.Unlock()
 }
 
+// RLock acquires the operation lock for reading. Call this before any Pi-hole API read.
+//
+// Holders of the shared lock run in parallel. That includes DNS and CNAME
+// record writes, which are ordered per record by LockRecord instead, so reads
+// do run during them and rely on the client's snapshots being invalidated by
+// each write. What the shared lock keeps reads out of are the exclusive
+// operations: client writes, DHCP lease removals, network device cleanups and
+// actions. Restarting DNS takes the whole API down until FTL is back, which
+// is why reads that never touch those tables, such as statistics, take the
+// lock as well.
+func (p *ProviderMeta) RLock() {
+	p.mu.RLock()
+}
+
+// RUnlock releases the shared operation lock. Always defer this after RLock().
+func (p *ProviderMeta) RUnlock() {
+	p.mu.RUnlock()
+}
+
+// LockRecord acquires the lock for a single record key, e.g. "dns/foo.lan".
+// Callers must also hold the shared lock via RLock().
+func (p *ProviderMeta) LockRecord(key string) {
+	p.recordsMu.Lock()
+	if p.records == nil {
+		p.records = make(map[string]*recordLock)
+	}
+	l, ok := p.records[key]
+	if !ok {
+		l = &recordLock{}
+		p.records[key] = l
+	}
+	l.refs++
+	p.recordsMu.Unlock()
+
+	l.mu.Lock()
+}
+
+// UnlockRecord releases the lock for a single record key. Always defer this after LockRecord().
+func (p *ProviderMeta) UnlockRecord(key string) {
+	p.recordsMu.Lock()
+	l := p.records[key]
+	l.refs--
+	if l.refs == 0 {
+		delete(p.records, key)
+	}
+	p.recordsMu.Unlock()
+
+	l.mu.Unlock()
+}
+
 // getProviderMeta extracts the ProviderMeta from the provider meta interface.
 // Returns an error diagnostic if it cannot be loaded.
 func getProviderMeta(meta interface{}) (*ProviderMeta, diag.Diagnostics) {
@@ -41,3 +115,37 @@ func getProviderMeta(meta interface{}) (*ProviderMeta, diag.Diagnostics) {
 	}
 	return pm, nil
 }
+
+// setSnapshotID sets the ID of a data source that reads values which change
+// constantly, such as statistics. The ID only depends on key, which names the
+// data source and its arguments, so it stays the same across reads.
+func setSnapshotID(d *schema.ResourceData, key string) {
+	d.SetId(key)
+}
+
+// diagFromErr converts an error into diagnostics. Errors returned by the
+// Pi-hole API carry Pi-hole's hint, which is shown as the diagnostic detail.
+func diagFromErr(err error) diag.Diagnostics {
+	var apiErr *pihole.APIError
+	if errors.As(err, &apiErr) && apiErr.Hint != "" {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   apiErr.Hint,
+		}}
+	}
+
+	return diag.FromErr(err)
+}
+
+// addErr adds err to the diagnostics of a framework resource, with the same
+// summary and detail as diagFromErr
+func addErr(diags *fwdiag.Diagnostics, err error) {
+	var apiErr *pihole.APIError
+	if errors.As(err, &apiErr) && apiErr.Hint != "" {
+		diags.AddError(err.Error(), apiErr.Hint)
+		return
+	}
+
+	diags.AddError(err.Error(), "")
+}
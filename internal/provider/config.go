@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 	v6 "github.com/poindexter12/terraform-provider-pihole/internal/pihole/v6"
@@ -21,20 +22,156 @@ type Config struct {
 	// Custom CA file
 	CAFile string
 
+	// Custom CA certificates, PEM encoded
+	CAPEM string
+
+	// Client certificate and key for mutual TLS, as files or inline PEM
+	ClientCertFile string
+	ClientCertPEM  string
+	ClientKeyFile  string
+	ClientKeyPEM   string
+
+	// TLSServerName overrides the host name the server certificate is verified against
+	TLSServerName string
+
 	// InsecureSkipVerify disables TLS certificate verification
 	InsecureSkipVerify bool
 
+	// Headers are sent with every request
+	Headers map[string]string
+
+	// HTTP basic authentication for a reverse proxy
+	BasicAuthUsername string
+	BasicAuthPassword string
+
+	// ProxyURL is the HTTP, HTTPS or SOCKS5 proxy to connect through
+	ProxyURL string
+
 	// SessionID can be passed to reduce the number of requests against the /api/auth endpoint
 	SessionID string
+
+	// RequestTimeout bounds each HTTP request attempt
+	RequestTimeout time.Duration
+
+	// MaxRetries is the number of times a failed request is retried
+	MaxRetries int
+
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// ConsistencyTimeout bounds how long record writes wait to become visible
+	ConsistencyTimeout time.Duration
+
+	// ReapStaleSessions revokes the sessions of earlier runs that have been
+	// idle for StaleSessionAge when logging in. Sessions are matched by the
+	// ReapUserAgent product token, and SessionJournal records the sessions
+	// of this machine's runs to recover a login rejected for lack of seats.
+	ReapStaleSessions bool
+	StaleSessionAge   time.Duration
+	ReapUserAgent     string
+	SessionJournal    string
+}
+
+// EnvVars are the environment variables ConfigFromEnv reads
+var EnvVars = []string{
+	"PIHOLE_URL",
+	"PIHOLE_PASSWORD",
+	"PIHOLE_CA_FILE",
+	"PIHOLE_CA_PEM",
+	"PIHOLE_CLIENT_CERT_FILE",
+	"PIHOLE_CLIENT_CERT_PEM",
+	"PIHOLE_CLIENT_KEY_FILE",
+	"PIHOLE_CLIENT_KEY_PEM",
+	"PIHOLE_TLS_SERVER_NAME",
+	"PIHOLE_BASIC_AUTH_USERNAME",
+	"PIHOLE_BASIC_AUTH_PASSWORD",
+	"PIHOLE_PROXY_URL",
+}
+
+// ConfigFromEnv returns the configuration of a provider block that sets
+// nothing, which reads the PIHOLE_* environment variables. Tools outside
+// Terraform use it to connect the same way the provider does.
+func ConfigFromEnv() Config {
+	sdk := Provider()
+	return configFromAttributes(func(name string) interface{} {
+		s := sdk.Schema[name]
+		if v, err := s.DefaultValue(); err == nil && v != nil {
+			return v
+		}
+		return s.ZeroValue()
+	})
+}
+
+// configFromAttributes builds a Config from the provider attributes returned
+// by get
+func configFromAttributes(get func(name string) interface{}) Config {
+	// Durations are validated by the schema
+	requestTimeout, _ := time.ParseDuration(get("request_timeout").(string))
+	retryWaitMin, _ := time.ParseDuration(get("retry_wait_min").(string))
+	retryWaitMax, _ := time.ParseDuration(get("retry_wait_max").(string))
+	consistencyTimeout, _ := time.ParseDuration(get("consistency_timeout").(string))
+	staleSessionAge, _ := time.ParseDuration(get("stale_session_age").(string))
+
+	headers := make(map[string]string)
+	for name, value := range get("headers").(map[string]interface{}) {
+		headers[name] = value.(string)
+	}
+
+	return Config{
+		Password:           get("password").(string),
+		URL:                get("url").(string),
+		CAFile:             get("ca_file").(string),
+		CAPEM:              get("ca_pem").(string),
+		ClientCertFile:     get("client_cert_file").(string),
+		ClientCertPEM:      get("client_cert_pem").(string),
+		ClientKeyFile:      get("client_key_file").(string),
+		ClientKeyPEM:       get("client_key_pem").(string),
+		TLSServerName:      get("tls_server_name").(string),
+		InsecureSkipVerify: get("insecure_skip_verify").(bool),
+		Headers:            headers,
+		BasicAuthUsername:  get("basic_auth_username").(string),
+		BasicAuthPassword:  get("basic_auth_password").(string),
+		ProxyURL:           get("proxy_url").(string),
+		RequestTimeout:     requestTimeout,
+		MaxRetries:         get("max_retries").(int),
+		RetryWaitMin:       retryWaitMin,
+		RetryWaitMax:       retryWaitMax,
+		ConsistencyTimeout: consistencyTimeout,
+		ReapStaleSessions:  get("reap_stale_sessions").(bool),
+		StaleSessionAge:    staleSessionAge,
+	}
 }
 
+// Client creates a Pi-hole client with the configuration
 func (c Config) Client(ctx context.Context) (pihole.Client, error) {
 	return v6.NewClient(ctx, pihole.Config{
 		BaseURL:            c.URL,
 		Password:           c.Password,
 		UserAgent:          c.UserAgent,
 		CAFile:             c.CAFile,
+		CAPEM:              c.CAPEM,
+		ClientCertFile:     c.ClientCertFile,
+		ClientCertPEM:      c.ClientCertPEM,
+		ClientKeyFile:      c.ClientKeyFile,
+		ClientKeyPEM:       c.ClientKeyPEM,
+		TLSServerName:      c.TLSServerName,
 		InsecureSkipVerify: c.InsecureSkipVerify,
 		SessionID:          c.SessionID,
+		Headers:            c.Headers,
+		BasicAuthUsername:  c.BasicAuthUsername,
+		BasicAuthPassword:  c.BasicAuthPassword,
+		ProxyURL:           c.ProxyURL,
+		RequestTimeout:     c.RequestTimeout,
+		Retry: &pihole.RetryPolicy{
+			MaxRetries: c.MaxRetries,
+			WaitMin:    c.RetryWaitMin,
+			WaitMax:    c.RetryWaitMax,
+		},
+		ConsistencyTimeout: c.ConsistencyTimeout,
+		ReapStaleSessions:  c.ReapStaleSessions,
+		StaleSessionAge:    c.StaleSessionAge,
+		ReapUserAgent:      c.ReapUserAgent,
+		SessionJournal:     c.SessionJournal,
 	})
 }
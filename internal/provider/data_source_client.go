@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// clientDataSource looks up a Pi-hole client configuration by identifier
+type clientDataSource struct {
+	pm *ProviderMeta
+}
+
+// clientDataModel is the state of a pihole_client data source
+type clientDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	Client       types.String `tfsdk:"client"`
+	AllowMissing types.Bool   `tfsdk:"allow_missing"`
+	Found        types.Bool   `tfsdk:"found"`
+	Comment      types.String `tfsdk:"comment"`
+	Type         types.String `tfsdk:"type"`
+	DatabaseID   types.Int64  `tfsdk:"database_id"`
+	Name         types.String `tfsdk:"name"`
+	Vendor       types.String `tfsdk:"vendor"`
+	Groups       types.List   `tfsdk:"groups"`
+	DateAdded    types.String `tfsdk:"date_added"`
+	DateModified types.String `tfsdk:"date_modified"`
+}
+
+var _ datasource.DataSourceWithConfigure = &clientDataSource{}
+
+// newClientDataSource returns the Pi-hole client data source
+func newClientDataSource() datasource.DataSource {
+	return &clientDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *clientDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client"
+}
+
+// Schema returns the data source schema
+func (d *clientDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Look up a Pi-hole client configuration by identifier",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of this resource.",
+				Computed:    true,
+			},
+			"client": schema.StringAttribute{
+				Description: "Client identifier (IP address, MAC address, hostname, CIDR range, or interface name prefixed with a colon such as `:eth0`)",
+				Required:    true,
+				Validators:  []validator.String{clientIDValidator()},
+			},
+			"allow_missing": schema.BoolAttribute{
+				Description: "If true, a missing client sets `found` to false instead of failing",
+				Optional:    true,
+			},
+			"found": schema.BoolAttribute{
+				Description: "Whether the client exists",
+				Computed:    true,
+			},
+			"comment": schema.StringAttribute{
+				Description: "Comment for the client, null if the client does not exist",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Kind of client identifier: `ipv4`, `ipv6`, `cidr`, `mac`, `hostname` or `interface`",
+				Computed:    true,
+			},
+			"database_id": schema.Int64Attribute{
+				Description: "ID of the client in Pi-hole's gravity database, null if the client does not exist",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Hostname Pi-hole resolved for the client, empty if it has none and null if the client does not exist",
+				Computed:    true,
+			},
+			"vendor": schema.StringAttribute{
+				Description: "Vendor of the network device the client refers to, empty for clients that are not a single known device and null if the client does not exist",
+				Computed:    true,
+			},
+			"groups": schema.ListAttribute{
+				Description: "IDs of the groups the client is assigned to in ascending order, null if the client does not exist",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"date_added": schema.StringAttribute{
+				Description: "When the client was added in RFC 3339 format, null if the client does not exist",
+				Computed:    true,
+			},
+			"date_modified": schema.StringAttribute{
+				Description: "When the client was last modified in RFC 3339 format, null if the client does not exist",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure stores the provider meta
+func (d *clientDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pm, diags := providerMetaFromData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.pm = pm
+}
+
+// Read looks up the client
+func (d *clientDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data clientDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.pm.RLock()
+	defer d.pm.RUnlock()
+
+	client := data.Client.ValueString()
+	data.ID = types.StringValue(client)
+	data.Type = clientType(client)
+
+	record, err := d.pm.Client.ClientManagement().Get(ctx, client)
+	switch {
+	case errors.Is(err, pihole.ErrClientNotFound) && data.AllowMissing.ValueBool():
+		data.Found = types.BoolValue(false)
+		data.Comment = types.StringNull()
+		data.DatabaseID = types.Int64Null()
+		data.Name = types.StringNull()
+		data.Vendor = types.StringNull()
+		data.Groups = types.ListNull(types.Int64Type)
+		data.DateAdded = types.StringNull()
+		data.DateModified = types.StringNull()
+	case err != nil:
+		addErr(&resp.Diagnostics, fmt.Errorf("%s: %w", client, err))
+		return
+	default:
+		devices, err := d.pm.Client.Network().List(ctx)
+		if err != nil {
+			resp.Diagnostics.AddWarning(vendorWarning(client, err))
+		}
+
+		attrs, diags := newClientAttributes(ctx, record, devices)
+		resp.Diagnostics.Append(diags...)
+
+		data.Found = types.BoolValue(true)
+		data.Comment = types.StringValue(record.Comment)
+		data.DatabaseID = attrs.DatabaseID
+		data.Name = attrs.Name
+		data.Vendor = attrs.Vendor
+		data.Groups = attrs.Groups
+		data.DateAdded = attrs.DateAdded
+		data.DateModified = attrs.DateModified
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"math"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestAccClientData(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_client" "client" {
+					  client  = "02:00:00:00:52:01"
+					  comment = "Looked up client"
+					}
+
+					data "pihole_client" "client" {
+					  client = upper(pihole_client.client.client)
+					}
+
+					data "pihole_client" "missing" {
+					  client        = "192.168.52.99"
+					  allow_missing = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_client.client", "found", "true"),
+					resource.TestCheckResourceAttr("data.pihole_client.client", "comment", "Looked up client"),
+					resource.TestCheckResourceAttr("data.pihole_client.client", "type", "mac"),
+					resource.TestCheckResourceAttrPair("data.pihole_client.client", "database_id", "pihole_client.client", "database_id"),
+					resource.TestCheckResourceAttrPair("data.pihole_client.client", "date_added", "pihole_client.client", "date_added"),
+					resource.TestCheckResourceAttr("data.pihole_client.client", "groups.#", "1"),
+
+					resource.TestCheckResourceAttr("data.pihole_client.missing", "found", "false"),
+					resource.TestCheckResourceAttr("data.pihole_client.missing", "type", "ipv4"),
+					resource.TestCheckNoResourceAttr("data.pihole_client.missing", "comment"),
+					resource.TestCheckNoResourceAttr("data.pihole_client.missing", "database_id"),
+				),
+			},
+			{
+				Config: `
+					data "pihole_client" "missing" {
+					  client = "192.168.52.99"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`192.168.52.99: client not found`),
+			},
+		},
+	})
+}
+
+// TestAccClientDataVendorUnavailable tests that clients are read without
+// their vendor when the network table cannot be read
+func TestAccClientDataVendorUnavailable(t *testing.T) {
+	testAccFake(t, func(srv *fake.Server) {
+		srv.AddClient("02:00:00:00:52:02", "Printer")
+		srv.AddDevice(fake.Device{HWAddr: "02:00:00:00:52:02", Vendor: "Brother Industries"})
+		srv.FailNetworkTable(math.MaxInt)
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "pihole" {
+					  max_retries = 0
+					}
+
+					data "pihole_client" "client" {
+					  client = "02:00:00:00:52:02"
+					}
+
+					data "pihole_clients" "all" {}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_client.client", "comment", "Printer"),
+					resource.TestCheckResourceAttr("data.pihole_client.client", "vendor", ""),
+					resource.TestCheckResourceAttr("data.pihole_clients.all", "clients.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_clients.all", "clients.0.vendor", ""),
+				),
+			},
+		},
+	})
+}
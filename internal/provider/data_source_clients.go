@@ -4,17 +4,43 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"slices"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
+// clientTypes are the values of the client type attributes
+var clientTypes = []string{
+	string(pihole.ClientIPv4),
+	string(pihole.ClientIPv6),
+	string(pihole.ClientCIDR),
+	string(pihole.ClientMAC),
+	string(pihole.ClientHostname),
+	string(pihole.ClientInterface),
+}
+
 // dataSourceClients returns a schema resource for listing Pi-hole clients
 func dataSourceClients() *schema.Resource {
 	return &schema.Resource{
 		Description: "List all Pi-hole client configurations",
 		ReadContext: dataSourceClientsRead,
 		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:      "Only include clients identified by this kind of identifier: `ipv4`, `ipv6`, `cidr`, `mac`, `hostname` or `interface`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(clientTypes, false)),
+			},
+			"groups": {
+				Description: "Only include clients in at least one of these groups, by group ID. The default group is `0`.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
 			"clients": {
 				Description: "List of Pi-hole client configurations",
 				Type:        schema.TypeSet,
@@ -31,42 +57,124 @@ func dataSourceClients() *schema.Resource {
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
+						"type": {
+							Description: "Kind of client identifier: `ipv4`, `ipv6`, `cidr`, `mac`, `hostname` or `interface`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"database_id": {
+							Description: "ID of the client in Pi-hole's gravity database, which groups refer to clients by",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Hostname Pi-hole resolved for the client, empty if it has none",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"vendor": {
+							Description: "Vendor of the network device the client refers to, empty for clients that are not a single known device",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"groups": {
+							Description: "IDs of the groups the client is assigned to, in ascending order",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"date_added": {
+							Description: "When the client was added, in RFC 3339 format",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"date_modified": {
+							Description: "When the client was last modified, in RFC 3339 format",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
 					},
 				},
 			},
+			"comments_by_client": {
+				Description: "Map of client identifier to comment",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
-// dataSourceClientsRead lists all Pi-hole client configurations
+// dataSourceClientsRead lists the Pi-hole client configurations matching the filters
 func dataSourceClientsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	pm, diags := getProviderMeta(meta)
 	if diags != nil {
 		return diags
 	}
 
-	pm.Lock()
-	defer pm.Unlock()
+	kind := pihole.ClientType(d.Get("type").(string))
+	var groups []int
+	for _, g := range d.Get("groups").(*schema.Set).List() {
+		groups = append(groups, g.(int))
+	}
+	sort.Ints(groups)
+
+	pm.RLock()
+	defer pm.RUnlock()
 
 	clientList, err := pm.Client.ClientManagement().List(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	list := make([]map[string]interface{}, len(clientList))
-	idRef := ""
+	devices, err := pm.Client.Network().List(ctx)
+	if err != nil {
+		summary, detail := vendorWarning("clients", err)
+		diags = append(diags, diag.Diagnostic{Severity: diag.Warning, Summary: summary, Detail: detail})
+	}
 
-	for i, c := range clientList {
-		idRef = fmt.Sprintf("%s|%s|", idRef, c.Client)
+	sort.Slice(clientList, func(i, j int) bool { return clientList[i].Client < clientList[j].Client })
+
+	list := make([]map[string]interface{}, 0, len(clientList))
+	byClient := make(map[string]interface{}, len(clientList))
+	idRef := fmt.Sprintf("%s|%v|", kind, groups)
 
-		list[i] = map[string]interface{}{
-			"client":  c.Client,
-			"comment": c.Comment,
+	for _, c := range clientList {
+		// Identifiers Pi-hole accepted but the provider cannot classify have no type
+		clientKind, _, _ := pihole.ParseClientID(c.Client)
+		if kind != "" && clientKind != kind {
+			continue
 		}
+		if len(groups) > 0 && !slices.ContainsFunc(c.Groups, func(g int) bool { return slices.Contains(groups, g) }) {
+			continue
+		}
+
+		idRef = fmt.Sprintf("%s|%s|", idRef, c.Client)
+
+		clientGroups := slices.Clone(c.Groups)
+		slices.Sort(clientGroups)
+		device, _ := pihole.ClientDevice(devices, c.Client)
+
+		list = append(list, map[string]interface{}{
+			"client":        c.Client,
+			"comment":       c.Comment,
+			"type":          string(clientKind),
+			"database_id":   c.ID,
+			"name":          c.Name,
+			"vendor":        device.Vendor,
+			"groups":        clientGroups,
+			"date_added":    formatTimestamp(c.DateAdded),
+			"date_modified": formatTimestamp(c.DateModified),
+		})
+		byClient[c.Client] = c.Comment
 	}
 
 	if err := d.Set("clients", list); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
+	}
+	if err := d.Set("comments_by_client", byClient); err != nil {
+		return diagFromErr(err)
 	}
 
 	hash := sha256.Sum256([]byte(idRef))
@@ -1,15 +1,16 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccClientsData(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -29,3 +30,51 @@ func TestAccClientsData(t *testing.T) {
 		},
 	})
 }
+
+func TestAccClientsDataFilters(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_client" "ip" {
+					  client  = "192.168.51.1"
+					  comment = "Filtered client"
+					}
+
+					resource "pihole_client" "mac" {
+					  client  = "02:00:00:00:51:01"
+					  comment = "Filtered MAC client"
+					}
+
+					data "pihole_clients" "mac" {
+					  type       = "mac"
+					  groups     = [0]
+					  depends_on = [pihole_client.ip, pihole_client.mac]
+					}
+
+					data "pihole_clients" "none" {
+					  groups     = [4095]
+					  depends_on = [pihole_client.ip, pihole_client.mac]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_clients.mac", "comments_by_client.02:00:00:00:51:01", "Filtered MAC client"),
+					resource.TestCheckNoResourceAttr("data.pihole_clients.mac", "comments_by_client.192.168.51.1"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.pihole_clients.mac", "clients.*", map[string]string{
+						"client":   "02:00:00:00:51:01",
+						"type":     "mac",
+						"groups.#": "1",
+						"groups.0": "0",
+					}),
+					resource.TestMatchTypeSetElemNestedAttrs("data.pihole_clients.mac", "clients.*", map[string]*regexp.Regexp{
+						"database_id": regexp.MustCompile(`^\d+$`),
+						"date_added":  rfc3339Regex,
+					}),
+					resource.TestCheckResourceAttr("data.pihole_clients.none", "clients.#", "0"),
+				),
+			},
+		},
+	})
+}
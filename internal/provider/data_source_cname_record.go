@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// cnameRecordDataSource looks up a CNAME record by domain
+type cnameRecordDataSource struct {
+	pm *ProviderMeta
+}
+
+// cnameRecordDataModel is the state of a pihole_dns_record data source
+type cnameRecordDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	Domain       types.String `tfsdk:"domain"`
+	AllowMissing types.Bool   `tfsdk:"allow_missing"`
+	Found        types.Bool   `tfsdk:"found"`
+	Target       types.String `tfsdk:"target"`
+}
+
+var _ datasource.DataSourceWithConfigure = &cnameRecordDataSource{}
+
+// newCNAMERecordDataSource returns the CNAME record data source
+func newCNAMERecordDataSource() datasource.DataSource {
+	return &cnameRecordDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *cnameRecordDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cname_record"
+}
+
+// Schema returns the data source schema
+func (d *cnameRecordDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Look up a Pi-hole CNAME record by domain",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of this resource.",
+				Computed:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "CNAME record domain",
+				Required:    true,
+				Validators:  []validator.String{domainValidator()},
+			},
+			"allow_missing": schema.BoolAttribute{
+				Description: "If true, a missing record sets `found` to false instead of failing",
+				Optional:    true,
+			},
+			"found": schema.BoolAttribute{
+				Description: "Whether the record exists",
+				Computed:    true,
+			},
+			"target": schema.StringAttribute{
+				Description: "CNAME target value where traffic is routed to from the domain, null if the record does not exist",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure stores the provider meta
+func (d *cnameRecordDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pm, diags := providerMetaFromData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.pm = pm
+}
+
+// Read looks up the record
+func (d *cnameRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data cnameRecordDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.pm.RLock()
+	defer d.pm.RUnlock()
+
+	domain := data.Domain.ValueString()
+	data.ID = types.StringValue(domain)
+
+	record, err := d.pm.Client.LocalCNAME().Get(ctx, domain)
+	switch {
+	case errors.Is(err, pihole.ErrCNAMENotFound) && data.AllowMissing.ValueBool():
+		data.Found = types.BoolValue(false)
+		data.Target = types.StringNull()
+	case err != nil:
+		addErr(&resp.Diagnostics, fmt.Errorf("%s: %w", domain, err))
+		return
+	default:
+		data.Found = types.BoolValue(true)
+		data.Target = types.StringValue(record.Target)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
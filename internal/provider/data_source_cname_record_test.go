@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCNAMERecordData(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_cname_record" "record" {
+					  domain = "alias.lookup.lan"
+					  target = "lookup.lan"
+					}
+
+					data "pihole_cname_record" "record" {
+					  domain = pihole_cname_record.record.domain
+					}
+
+					data "pihole_cname_record" "missing" {
+					  domain        = "missing.lookup.lan"
+					  allow_missing = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_cname_record.record", "found", "true"),
+					resource.TestCheckResourceAttr("data.pihole_cname_record.record", "target", "lookup.lan"),
+
+					resource.TestCheckResourceAttr("data.pihole_cname_record.missing", "found", "false"),
+					resource.TestCheckNoResourceAttr("data.pihole_cname_record.missing", "target"),
+				),
+			},
+			{
+				Config: `
+					data "pihole_cname_record" "missing" {
+					  domain = "missing.lookup.lan"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`missing.lookup.lan: local CNAME record not found`),
+			},
+		},
+	})
+}
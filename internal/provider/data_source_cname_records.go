@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
 // dataSourceCNAMERecords returns a schema resource for listing Pi-hole CNAME records
@@ -14,6 +16,13 @@ func dataSourceCNAMERecords() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceCNAMERecordsRead,
 		Schema: map[string]*schema.Schema{
+			"domain_suffix": domainSuffixSchema(),
+			"domain_regex":  domainRegexSchema(),
+			"target": {
+				Description: "Only include records pointing to this target domain",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
 			"records": {
 				Description: "List of CNAME Pi-hole records",
 				Type:        schema.TypeSet,
@@ -33,40 +42,69 @@ func dataSourceCNAMERecords() *schema.Resource {
 					},
 				},
 			},
+			"targets_by_domain": {
+				Description: "Map of CNAME record domain to target",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
-// dataSourceCNAMERecordsRead lists all Pi-hole CNAME records
+// dataSourceCNAMERecordsRead lists the Pi-hole CNAME records matching the filters
 func dataSourceCNAMERecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	pm, diags := getProviderMeta(meta)
 	if diags != nil {
 		return diags
 	}
 
-	// Acquire global mutex to serialize all Pi-hole API operations
-	pm.Lock()
-	defer pm.Unlock()
+	filter := domainFilterFromData(d)
+	target := pihole.NormalizeDomain(d.Get("target").(string))
+
+	pm.RLock()
+	defer pm.RUnlock()
 
 	cnameList, err := pm.Client.LocalCNAME().List(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	list := make([]map[string]interface{}, len(cnameList))
-	idRef := ""
+	sort.Slice(cnameList, func(i, j int) bool {
+		if cnameList[i].Domain != cnameList[j].Domain {
+			return cnameList[i].Domain < cnameList[j].Domain
+		}
+		return cnameList[i].Target < cnameList[j].Target
+	})
+
+	list := make([]map[string]interface{}, 0, len(cnameList))
+	byDomain := make(map[string]interface{}, len(cnameList))
+	idRef := fmt.Sprintf("%s|%s|%s|", filter.suffix, filter.pattern, target)
+
+	for _, r := range cnameList {
+		if !filter.match(r.Domain) {
+			continue
+		}
+		if target != "" && pihole.NormalizeDomain(r.Target) != target {
+			continue
+		}
 
-	for i, r := range cnameList {
 		idRef = fmt.Sprintf("%s|%s|%s|", idRef, r.Domain, r.Target)
 
-		list[i] = map[string]interface{}{
+		list = append(list, map[string]interface{}{
 			"domain": r.Domain,
 			"target": r.Target,
+		})
+		if _, ok := byDomain[r.Domain]; !ok {
+			byDomain[r.Domain] = r.Target
 		}
 	}
 
 	if err := d.Set("records", list); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
+	}
+	if err := d.Set("targets_by_domain", byDomain); err != nil {
+		return diagFromErr(err)
 	}
 
 	hash := sha256.Sum256([]byte(idRef))
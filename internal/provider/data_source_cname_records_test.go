@@ -7,9 +7,9 @@ import (
 )
 
 func TestAccCNAMERecordsData(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -32,3 +32,42 @@ func TestAccCNAMERecordsData(t *testing.T) {
 		},
 	})
 }
+
+func TestAccCNAMERecordsDataFilters(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_cname_record" "www" {
+					  domain = "www.filter.lan"
+					  target = "web.filter.lan"
+					}
+
+					resource "pihole_cname_record" "blog" {
+					  domain = "blog.filter.lan"
+					  target = "web.filter.lan"
+					}
+
+					resource "pihole_cname_record" "mail" {
+					  domain = "mail.filter.lan"
+					  target = "mx.filter.lan"
+					}
+
+					data "pihole_cname_records" "web" {
+					  domain_suffix = "filter.lan"
+					  target        = "WEB.filter.lan."
+					  depends_on    = [pihole_cname_record.www, pihole_cname_record.blog, pihole_cname_record.mail]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_cname_records.web", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.web", "targets_by_domain.%", "2"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.web", "targets_by_domain.www.filter.lan", "web.filter.lan"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.web", "targets_by_domain.blog.filter.lan", "web.filter.lan"),
+				),
+			},
+		},
+	})
+}
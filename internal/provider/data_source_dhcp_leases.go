@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceDHCPLeases returns a schema resource for listing Pi-hole DHCP leases
+func dataSourceDHCPLeases() *schema.Resource {
+	return &schema.Resource{
+		Description: "List the active leases of Pi-hole's DHCP server",
+		ReadContext: dataSourceDHCPLeasesRead,
+		Schema: map[string]*schema.Schema{
+			"hwaddr": {
+				Description: "Only include leases of this hardware (MAC) address, compared case-insensitively",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ip": {
+				Description:      "Only include the lease of this IP address",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateIPAddress(),
+			},
+			"name": {
+				Description: "Only include leases with this host name",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"leases": {
+				Description: "List of DHCP leases",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Description: "Leased IP address",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"hwaddr": {
+							Description: "Hardware (MAC) address of the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Host name sent by the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"expires": {
+							Description: "When the lease expires (RFC 3339), empty for infinite leases",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"clientid": {
+							Description: "DHCP client identifier sent by the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceDHCPLeasesRead lists the active DHCP leases
+func dataSourceDHCPLeasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	hwaddr := d.Get("hwaddr").(string)
+	ip := d.Get("ip").(string)
+	name := d.Get("name").(string)
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	leases, err := pm.Client.DHCP().List(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	list := make([]map[string]interface{}, 0, len(leases))
+	idRef := fmt.Sprintf("%s|%s|%s|", hwaddr, ip, name)
+
+	for _, l := range leases {
+		if hwaddr != "" && !strings.EqualFold(l.HWAddr, hwaddr) {
+			continue
+		}
+		if ip != "" && l.IP != ip {
+			continue
+		}
+		if name != "" && l.Name != name {
+			continue
+		}
+
+		idRef = fmt.Sprintf("%s|%s|", idRef, l.IP)
+
+		list = append(list, map[string]interface{}{
+			"ip":       l.IP,
+			"hwaddr":   l.HWAddr,
+			"name":     l.Name,
+			"expires":  formatTimestamp(l.Expires),
+			"clientid": l.ClientID,
+		})
+	}
+
+	if err := d.Set("leases", list); err != nil {
+		return diagFromErr(err)
+	}
+
+	hash := sha256.Sum256([]byte(idRef))
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+
+	return diags
+}
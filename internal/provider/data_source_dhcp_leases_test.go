@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+const testAccDHCPLeasesDataConfig = `
+	data "pihole_dhcp_leases" "all" {}
+
+	data "pihole_dhcp_leases" "laptop" {
+	  hwaddr = "AA:BB:CC:DD:EE:01"
+	}
+`
+
+func TestAccDHCPLeasesData(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDHCPLeasesDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_dhcp_leases.all", "leases.#"),
+					resource.TestCheckResourceAttrSet("data.pihole_dhcp_leases.laptop", "leases.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDHCPLeasesDataValues(t *testing.T) {
+	testAccFake(t, func(srv *fake.Server) {
+		srv.AddLease(fake.Lease{IP: "192.0.2.10", HWAddr: "aa:bb:cc:dd:ee:01", Name: "laptop", Expires: 1700000000})
+		srv.AddLease(fake.Lease{IP: "192.0.2.11", HWAddr: "aa:bb:cc:dd:ee:02", Name: "phone"})
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDHCPLeasesDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_dhcp_leases.all", "leases.#", "2"),
+					resource.TestCheckResourceAttr("data.pihole_dhcp_leases.laptop", "leases.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_dhcp_leases.laptop", "leases.0.name", "laptop"),
+					resource.TestCheckResourceAttr("data.pihole_dhcp_leases.laptop", "leases.0.expires", "2023-11-14T22:13:20Z"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// dnsRecordDataSource looks up a local DNS record by domain
+type dnsRecordDataSource struct {
+	pm *ProviderMeta
+}
+
+// dnsRecordDataModel is the state of a pihole_dns_record data source
+type dnsRecordDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	Domain       types.String `tfsdk:"domain"`
+	AllowMissing types.Bool   `tfsdk:"allow_missing"`
+	Found        types.Bool   `tfsdk:"found"`
+	IP           types.String `tfsdk:"ip"`
+}
+
+var _ datasource.DataSourceWithConfigure = &dnsRecordDataSource{}
+
+// newDNSRecordDataSource returns the local DNS record data source
+func newDNSRecordDataSource() datasource.DataSource {
+	return &dnsRecordDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *dnsRecordDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+// Schema returns the data source schema
+func (d *dnsRecordDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Look up a Pi-hole DNS record by domain",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of this resource.",
+				Computed:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "DNS record domain",
+				Required:    true,
+				Validators:  []validator.String{domainValidator()},
+			},
+			"allow_missing": schema.BoolAttribute{
+				Description: "If true, a missing record sets `found` to false instead of failing",
+				Optional:    true,
+			},
+			"found": schema.BoolAttribute{
+				Description: "Whether the record exists",
+				Computed:    true,
+			},
+			"ip": schema.StringAttribute{
+				Description: "IP address where traffic is routed to from the DNS record domain, null if the record does not exist. " +
+					"A domain with several records resolves to its lowest IP address, IPv4 addresses before IPv6 ones.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure stores the provider meta
+func (d *dnsRecordDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	pm, diags := providerMetaFromData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.pm = pm
+}
+
+// Read looks up the record
+func (d *dnsRecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data dnsRecordDataModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.pm.RLock()
+	defer d.pm.RUnlock()
+
+	domain := data.Domain.ValueString()
+	data.ID = types.StringValue(domain)
+
+	ip, err := d.lookup(ctx, domain)
+	switch {
+	case errors.Is(err, pihole.ErrDNSNotFound) && data.AllowMissing.ValueBool():
+		data.Found = types.BoolValue(false)
+		data.IP = types.StringNull()
+	case err != nil:
+		addErr(&resp.Diagnostics, fmt.Errorf("%s: %w", domain, err))
+		return
+	default:
+		data.Found = types.BoolValue(true)
+		data.IP = types.StringValue(ip)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// lookup returns the IP address of domain. A domain with several records
+// resolves to its lowest IP address, the same one ips_by_domain of the
+// pihole_dns_records data source maps it to.
+func (d *dnsRecordDataSource) lookup(ctx context.Context, domain string) (string, error) {
+	records, err := d.pm.Client.LocalDNS().List(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var ip string
+	for _, r := range records {
+		if r.Domain == domain && (ip == "" || compareIPs(r.IP, ip) < 0) {
+			ip = r.IP
+		}
+	}
+	if ip == "" {
+		return "", pihole.ErrDNSNotFound
+	}
+	return ip, nil
+}
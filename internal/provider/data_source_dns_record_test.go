@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestAccDNSRecordData(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_dns_record" "record" {
+					  domain = "lookup.lan"
+					  ip     = "127.0.0.5"
+					}
+
+					data "pihole_dns_record" "record" {
+					  domain = pihole_dns_record.record.domain
+					}
+
+					data "pihole_dns_record" "missing" {
+					  domain        = "missing.lookup.lan"
+					  allow_missing = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_dns_record.record", "found", "true"),
+					resource.TestCheckResourceAttr("data.pihole_dns_record.record", "ip", "127.0.0.5"),
+
+					resource.TestCheckResourceAttr("data.pihole_dns_record.missing", "found", "false"),
+					resource.TestCheckNoResourceAttr("data.pihole_dns_record.missing", "ip"),
+				),
+			},
+			{
+				Config: `
+					data "pihole_dns_record" "missing" {
+					  domain = "missing.lookup.lan"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`missing.lookup.lan: local DNS record not found`),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecordDataLowestIP(t *testing.T) {
+	testAccFake(t, func(srv *fake.Server) {
+		srv.SetHosts("fd00::1 multi.lan", "10.0.0.10 multi.lan", "10.0.0.9 multi.lan")
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_dns_record" "record" {
+					  domain = "multi.lan"
+					}
+
+					data "pihole_dns_records" "records" {}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_dns_record.record", "ip", "10.0.0.9"),
+					resource.TestCheckResourceAttrPair("data.pihole_dns_record.record", "ip", "data.pihole_dns_records.records", "ips_by_domain.multi.lan"),
+				),
+			},
+		},
+	})
+}
@@ -4,9 +4,15 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
 // dataSourceDNSRecords returns a schema resource for listing Pi-hole local DNS records
@@ -14,6 +20,14 @@ func dataSourceDNSRecords() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceDNSRecordsRead,
 		Schema: map[string]*schema.Schema{
+			"domain_suffix": domainSuffixSchema(),
+			"domain_regex":  domainRegexSchema(),
+			"ip_cidr": {
+				Description:      "Only include records with an IP address in this CIDR range, such as `192.168.1.0/24`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsCIDR),
+			},
 			"records": {
 				Description: "List of Pi-hole DNS records",
 				Type:        schema.TypeSet,
@@ -33,40 +47,77 @@ func dataSourceDNSRecords() *schema.Resource {
 					},
 				},
 			},
+			"ips_by_domain": {
+				Description: "Map of record domain to IP address. A domain with several records maps to its lowest IP address, IPv4 addresses before IPv6 ones.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
-// dataSourceDNSRecordsRead lists all Pi-hole local DNS records
+// dataSourceDNSRecordsRead lists the Pi-hole local DNS records matching the filters
 func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	pm, diags := getProviderMeta(meta)
 	if diags != nil {
 		return diags
 	}
 
-	// Acquire global mutex to serialize all Pi-hole API operations
-	pm.Lock()
-	defer pm.Unlock()
+	filter := domainFilterFromData(d)
+
+	// The CIDR range is checked by validation.IsCIDR
+	var ipCIDR netip.Prefix
+	if v := d.Get("ip_cidr").(string); v != "" {
+		ipCIDR, _ = netip.ParsePrefix(v)
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
 
 	dnsList, err := pm.Client.LocalDNS().List(ctx)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
 	}
 
-	list := make([]map[string]interface{}, len(dnsList))
-	idRef := ""
+	sort.Slice(dnsList, func(i, j int) bool {
+		if dnsList[i].Domain != dnsList[j].Domain {
+			return dnsList[i].Domain < dnsList[j].Domain
+		}
+		return compareIPs(dnsList[i].IP, dnsList[j].IP) < 0
+	})
+
+	list := make([]map[string]interface{}, 0, len(dnsList))
+	byDomain := make(map[string]interface{}, len(dnsList))
+	idRef := fmt.Sprintf("%s|%s|%s|", filter.suffix, filter.pattern, d.Get("ip_cidr"))
+
+	for _, r := range dnsList {
+		if !filter.match(r.Domain) {
+			continue
+		}
+		if ipCIDR.IsValid() {
+			ip, err := netip.ParseAddr(r.IP)
+			if err != nil || !ipCIDR.Contains(ip.Unmap()) {
+				continue
+			}
+		}
 
-	for i, r := range dnsList {
 		idRef = fmt.Sprintf("%s|%s|%s|", idRef, r.Domain, r.IP)
 
-		list[i] = map[string]interface{}{
+		list = append(list, map[string]interface{}{
 			"domain": r.Domain,
 			"ip":     r.IP,
+		})
+		if _, ok := byDomain[r.Domain]; !ok {
+			byDomain[r.Domain] = r.IP
 		}
 	}
 
 	if err := d.Set("records", list); err != nil {
-		return diag.FromErr(err)
+		return diagFromErr(err)
+	}
+	if err := d.Set("ips_by_domain", byDomain); err != nil {
+		return diagFromErr(err)
 	}
 
 	hash := sha256.Sum256([]byte(idRef))
@@ -74,3 +125,73 @@ func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, meta
 
 	return diags
 }
+
+// compareIPs orders IP addresses numerically, IPv4 before IPv6, and anything
+// that is not an IP address after them as a string
+func compareIPs(a, b string) int {
+	ipA, errA := netip.ParseAddr(a)
+	ipB, errB := netip.ParseAddr(b)
+	switch {
+	case errA == nil && errB == nil:
+		return ipA.Compare(ipB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// domainSuffixSchema returns the schema of the domain_suffix filter
+func domainSuffixSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Only include records for this domain and its subdomains, such as `lan` or `home.arpa`",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+}
+
+// domainRegexSchema returns the schema of the domain_regex filter
+func domainRegexSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:      "Only include records with a domain matching this regular expression (RE2 syntax)",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+	}
+}
+
+// domainFilter selects records by domain. Zero values match every domain.
+type domainFilter struct {
+	suffix  string
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// domainFilterFromData reads the domain_suffix and domain_regex attributes of d
+func domainFilterFromData(d *schema.ResourceData) domainFilter {
+	f := domainFilter{
+		suffix:  d.Get("domain_suffix").(string),
+		pattern: d.Get("domain_regex").(string),
+	}
+
+	// The expression is checked by validation.StringIsValidRegExp
+	if f.pattern != "" {
+		f.regex, _ = regexp.Compile(f.pattern)
+	}
+
+	return f
+}
+
+// match reports whether domain passes the filter
+func (f domainFilter) match(domain string) bool {
+	if !pihole.HasDomainSuffix(domain, f.suffix) {
+		return false
+	}
+
+	if f.regex != nil && !f.regex.MatchString(domain) {
+		return false
+	}
+
+	return true
+}
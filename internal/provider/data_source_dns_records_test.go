@@ -1,14 +1,16 @@
 package provider
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
 )
 
 func TestAccDNSRecordsData(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: `
@@ -31,3 +33,76 @@ func TestAccDNSRecordsData(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDNSRecordsDataFilters(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_dns_record" "nas" {
+					  domain = "nas.filter.lan"
+					  ip     = "10.60.0.1"
+					}
+
+					resource "pihole_dns_record" "nas6" {
+					  domain = "nas6.filter.lan"
+					  ip     = "fd00::60"
+					}
+
+					resource "pihole_dns_record" "printer" {
+					  domain = "printer.filter.lan"
+					  ip     = "10.61.0.1"
+					}
+
+					resource "pihole_dns_record" "other" {
+					  domain = "nas.filterother.lan"
+					  ip     = "10.60.0.2"
+					}
+
+					data "pihole_dns_records" "suffix" {
+					  domain_suffix = "filter.lan"
+					  depends_on    = [pihole_dns_record.nas, pihole_dns_record.nas6, pihole_dns_record.printer, pihole_dns_record.other]
+					}
+
+					data "pihole_dns_records" "cidr" {
+					  domain_regex = "^nas\\."
+					  ip_cidr      = "10.60.0.0/16"
+					  depends_on   = [pihole_dns_record.nas, pihole_dns_record.nas6, pihole_dns_record.printer, pihole_dns_record.other]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_dns_records.suffix", "records.#", "3"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.suffix", "ips_by_domain.%", "3"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.suffix", "ips_by_domain.nas.filter.lan", "10.60.0.1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.suffix", "ips_by_domain.nas6.filter.lan", "fd00::60"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.suffix", "ips_by_domain.printer.filter.lan", "10.61.0.1"),
+
+					resource.TestCheckResourceAttr("data.pihole_dns_records.cidr", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.cidr", "ips_by_domain.nas.filter.lan", "10.60.0.1"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.cidr", "ips_by_domain.nas.filterother.lan", "10.60.0.2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecordsDataLowestIP(t *testing.T) {
+	testAccFake(t, func(srv *fake.Server) {
+		srv.SetHosts("fd00::1 multi.lan", "10.0.0.10 multi.lan", "10.0.0.9 multi.lan")
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_dns_records" "records" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_dns_records.records", "records.#", "3"),
+					resource.TestCheckResourceAttr("data.pihole_dns_records.records", "ips_by_domain.multi.lan", "10.0.0.9"),
+				),
+			},
+		},
+	})
+}
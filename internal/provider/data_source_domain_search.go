@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// dataSourceDomainSearch returns a schema resource for explaining whether Pi-hole blocks a domain
+func dataSourceDomainSearch() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up the allow/deny entries and subscribed lists matching a domain, to find out whether and why Pi-hole blocks it",
+		ReadContext: dataSourceDomainSearchRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description:      "Domain to search for",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			"partial": {
+				Description: "Also match entries containing `domain`, such as `ads.example.com` when searching for `example.com`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"limit": {
+				Description:      "Maximum number of matches returned for `domains` and for `gravity`",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          20,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"blocked": {
+				Description: "Whether the matches block `domain`, following Pi-hole's precedence of exact allow, exact deny, regex allow, regex deny, list allow and list block. Disabled entries are ignored; group assignments are not taken into account.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"exact_matches": {
+				Description: "Number of matching exact allow/deny entries",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"regex_matches": {
+				Description: "Number of matching regex allow/deny entries",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"gravity_allow_matches": {
+				Description: "Number of matching allowlist entries",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"gravity_block_matches": {
+				Description: "Number of matching blocklist entries",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"domains": {
+				Description: "Matching exact and regex allow/deny entries",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the entry",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "Domain or regular expression of the entry",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "`allow` or `deny`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"kind": {
+							Description: "`exact` or `regex`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"comment": {
+							Description: "Comment of the entry",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"enabled": {
+							Description: "Whether the entry is enabled",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"groups": {
+							Description: "IDs of the groups the entry applies to",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+			"gravity": {
+				Description: "Matching entries of subscribed allow and block lists",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "ID of the list",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "Listed domain",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"address": {
+							Description: "URL of the list",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "`allow` or `block`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"comment": {
+							Description: "Comment of the list",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"enabled": {
+							Description: "Whether the list is enabled",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"groups": {
+							Description: "IDs of the groups the list applies to",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceDomainSearchRead searches for the entries matching a domain
+func dataSourceDomainSearchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	domain := d.Get("domain").(string)
+	opts := pihole.SearchOptions{
+		Partial: d.Get("partial").(bool),
+		Limit:   d.Get("limit").(int),
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	result, err := pm.Client.Search().Domain(ctx, domain, opts)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	domains := make([]map[string]interface{}, len(result.Domains))
+	for i, m := range result.Domains {
+		domains[i] = map[string]interface{}{
+			"id":      m.ID,
+			"domain":  m.Domain,
+			"type":    m.Type,
+			"kind":    m.Kind,
+			"comment": m.Comment,
+			"enabled": m.Enabled,
+			"groups":  m.Groups,
+		}
+	}
+
+	gravity := make([]map[string]interface{}, len(result.Gravity))
+	for i, m := range result.Gravity {
+		gravity[i] = map[string]interface{}{
+			"id":      m.ID,
+			"domain":  m.Domain,
+			"address": m.Address,
+			"type":    m.Type,
+			"comment": m.Comment,
+			"enabled": m.Enabled,
+			"groups":  m.Groups,
+		}
+	}
+
+	values := map[string]interface{}{
+		"blocked":               result.Blocked(domain),
+		"exact_matches":         result.ExactMatches,
+		"regex_matches":         result.RegexMatches,
+		"gravity_allow_matches": result.GravityAllowMatches,
+		"gravity_block_matches": result.GravityBlockMatches,
+		"domains":               domains,
+		"gravity":               gravity,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s|%t|%d", domain, opts.Partial, opts.Limit))
+
+	return diags
+}
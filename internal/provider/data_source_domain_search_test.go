@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+const testAccDomainSearchDataConfig = `
+	data "pihole_domain_search" "allowed" {
+	  domain = "tf-acc-test.invalid"
+	}
+
+	data "pihole_domain_search" "ads" {
+	  domain = "ads.example.com"
+	}
+`
+
+func TestAccDomainSearchData(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainSearchDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_domain_search.allowed", "blocked", "false"),
+					resource.TestCheckResourceAttr("data.pihole_domain_search.allowed", "domains.#", "0"),
+					resource.TestCheckResourceAttrSet("data.pihole_domain_search.ads", "blocked"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainSearchDataValues(t *testing.T) {
+	testAccFake(t, func(srv *fake.Server) {
+		srv.AddDomainEntry(fake.DomainEntry{Domain: `^ads\.`, Type: "deny", Kind: "regex", Enabled: true, Groups: []int{0, 1}})
+		srv.AddGravityEntry(fake.GravityEntry{Domain: "ads.example.com", Address: "https://lists.example.com/ads.txt", Type: "block", Enabled: true, Groups: []int{0}})
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainSearchDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_domain_search.ads", "blocked", "true"),
+					resource.TestCheckResourceAttr("data.pihole_domain_search.ads", "regex_matches", "1"),
+					resource.TestCheckResourceAttr("data.pihole_domain_search.ads", "domains.0.kind", "regex"),
+					resource.TestCheckResourceAttr("data.pihole_domain_search.ads", "domains.0.groups.#", "2"),
+					resource.TestCheckResourceAttr("data.pihole_domain_search.ads", "gravity.0.address", "https://lists.example.com/ads.txt"),
+				),
+			},
+		},
+	})
+}
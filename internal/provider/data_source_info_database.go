@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceInfoDatabase returns a schema resource for Pi-hole's long-term query database
+func dataSourceInfoDatabase() *schema.Resource {
+	return &schema.Resource{
+		Description: "Size and contents of Pi-hole's long-term query database. " +
+			"The values change on every read, so use them for outputs and inventory rather than resource arguments.",
+		ReadContext: dataSourceInfoDatabaseRead,
+		Schema: map[string]*schema.Schema{
+			"size": {
+				Description: "Size of the database file in bytes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"queries": {
+				Description: "Number of queries stored in the database",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"earliest_timestamp": {
+				Description: "When the oldest stored query was received (RFC 3339)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"modified_at": {
+				Description: "When the database file was last modified (RFC 3339)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sqlite_version": {
+				Description: "Version of the embedded SQLite library",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceInfoDatabaseRead reads the query database information
+func dataSourceInfoDatabaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	info, err := pm.Client.Info().Database(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"size":               info.Size,
+		"queries":            info.Queries,
+		"earliest_timestamp": formatTimestamp(info.EarliestTimestamp),
+		"modified_at":        formatTimestamp(info.ModifiedAt),
+		"sqlite_version":     info.SQLiteVersion,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	setSnapshotID(d, "database")
+
+	return diags
+}
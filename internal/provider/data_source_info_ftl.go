@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceInfoFTL returns a schema resource for the pihole-FTL process and component versions
+func dataSourceInfoFTL() *schema.Resource {
+	return &schema.Resource{
+		Description: "The running pihole-FTL process and the installed Pi-hole versions. " +
+			"Usage values change on every read, so use them for outputs and inventory rather than resource arguments.",
+		ReadContext: dataSourceInfoFTLRead,
+		Schema: map[string]*schema.Schema{
+			"pid": {
+				Description: "Process ID of pihole-FTL",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"uptime": {
+				Description: "Uptime of pihole-FTL in seconds",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"version": {
+				Description: "Installed pihole-FTL version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"core_version": {
+				Description: "Installed Pi-hole core version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"web_version": {
+				Description: "Installed web interface version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"docker_tag": {
+				Description: "Docker image tag, or empty when not running in Docker",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"privacy_level": {
+				Description: "Privacy level, from 0 (show everything) to 3 (anonymous mode)",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"memory_percent": {
+				Description: "Share of host memory used by pihole-FTL in percent",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"cpu_percent": {
+				Description: "CPU utilization of pihole-FTL in percent",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"query_frequency": {
+				Description: "Queries per second",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"active_clients": {
+				Description: "Number of clients that sent queries recently",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"total_clients": {
+				Description: "Number of clients ever seen",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"gravity_domains": {
+				Description: "Number of domains on the gravity lists",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"allow_destructive": {
+				Description: "Whether the API allows destructive actions such as restarting DNS or flushing logs",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceInfoFTLRead reads the pihole-FTL process information and component versions
+func dataSourceInfoFTLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	info, err := pm.Client.Info().FTL(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	version, err := pm.Client.Info().Version(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"pid":               info.PID,
+		"uptime":            int(info.Uptime.Seconds()),
+		"version":           version.FTL.Version,
+		"core_version":      version.Core.Version,
+		"web_version":       version.Web.Version,
+		"docker_tag":        version.Docker,
+		"privacy_level":     info.PrivacyLevel,
+		"memory_percent":    info.MemoryPercent,
+		"cpu_percent":       info.CPUPercent,
+		"query_frequency":   info.QueryFrequency,
+		"active_clients":    info.ActiveClients,
+		"total_clients":     info.TotalClients,
+		"gravity_domains":   info.GravityDomains,
+		"allow_destructive": info.AllowDestructive,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	setSnapshotID(d, "ftl")
+
+	return diags
+}
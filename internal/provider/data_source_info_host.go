@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceInfoHost returns a schema resource for the identity of the Pi-hole host
+func dataSourceInfoHost() *schema.Resource {
+	return &schema.Resource{
+		Description: "Hostname, kernel and hardware model of the host running Pi-hole",
+		ReadContext: dataSourceInfoHostRead,
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Description: "Hostname of the host",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sysname": {
+				Description: "Operating system name, such as `Linux`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"release": {
+				Description: "Kernel release",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"version": {
+				Description: "Kernel version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"machine": {
+				Description: "Hardware architecture, such as `aarch64`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"model": {
+				Description: "Hardware model, if Pi-hole can detect it",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceInfoHostRead reads the identity of the Pi-hole host
+func dataSourceInfoHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	info, err := pm.Client.Info().Host(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"hostname": info.Hostname,
+		"sysname":  info.Sysname,
+		"release":  info.Release,
+		"version":  info.Version,
+		"machine":  info.Machine,
+		"model":    info.Model,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	setSnapshotID(d, "host")
+
+	return diags
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceInfoSensors returns a schema resource for the temperature sensors of the Pi-hole host
+func dataSourceInfoSensors() *schema.Resource {
+	return &schema.Resource{
+		Description: "CPU temperature and hardware sensor readings of the host running Pi-hole. " +
+			"The values change on every read, so use them for outputs and inventory rather than resource arguments.",
+		ReadContext: dataSourceInfoSensorsRead,
+		Schema: map[string]*schema.Schema{
+			"cpu_temp": {
+				Description: "CPU temperature",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"hot_limit": {
+				Description: "Temperature above which the web interface warns about the CPU temperature",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"unit": {
+				Description: "Temperature unit of all readings: `C`, `F` or `K`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sensors": {
+				Description: "Hardware sensors found on the host",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the sensor",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"path": {
+							Description: "Path of the sensor in sysfs",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"temps": {
+							Description: "Temperature readings of the sensor",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Description: "Name of the reading",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"value": {
+										Description: "Current temperature",
+										Type:        schema.TypeFloat,
+										Computed:    true,
+									},
+									"max": {
+										Description: "High temperature threshold, or 0 if unknown",
+										Type:        schema.TypeFloat,
+										Computed:    true,
+									},
+									"crit": {
+										Description: "Critical temperature threshold, or 0 if unknown",
+										Type:        schema.TypeFloat,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceInfoSensorsRead reads the temperature sensors of the Pi-hole host
+func dataSourceInfoSensorsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	info, err := pm.Client.Info().Sensors(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	sensors := make([]map[string]interface{}, 0, len(info.Sensors))
+	for _, s := range info.Sensors {
+		temps := make([]map[string]interface{}, 0, len(s.Temps))
+		for _, t := range s.Temps {
+			temps = append(temps, map[string]interface{}{
+				"name":  t.Name,
+				"value": t.Value,
+				"max":   t.Max,
+				"crit":  t.Crit,
+			})
+		}
+		sensors = append(sensors, map[string]interface{}{
+			"name":  s.Name,
+			"path":  s.Path,
+			"temps": temps,
+		})
+	}
+
+	values := map[string]interface{}{
+		"cpu_temp":  info.CPUTemp,
+		"hot_limit": info.HotLimit,
+		"unit":      info.Unit,
+		"sensors":   sensors,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	setSnapshotID(d, "sensors")
+
+	return diags
+}
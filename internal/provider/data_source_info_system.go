@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceInfoSystem returns a schema resource for the resource usage of the Pi-hole host
+func dataSourceInfoSystem() *schema.Resource {
+	return &schema.Resource{
+		Description: "Uptime, memory, CPU and load of the host running Pi-hole. " +
+			"The values change on every read, so use them for outputs and inventory rather than resource arguments.",
+		ReadContext: dataSourceInfoSystemRead,
+		Schema: map[string]*schema.Schema{
+			"uptime": {
+				Description: "Host uptime in seconds",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"processes": {
+				Description: "Number of running processes",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"memory_total": {
+				Description: "Total memory in KiB",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"memory_used": {
+				Description: "Used memory in KiB",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"memory_available": {
+				Description: "Memory available for new processes in KiB",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"memory_percent_used": {
+				Description: "Percentage of memory used",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"swap_total": {
+				Description: "Total swap in KiB",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"swap_used": {
+				Description: "Used swap in KiB",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"swap_percent_used": {
+				Description: "Percentage of swap used",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"cpu_cores": {
+				Description: "Number of CPU cores",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"cpu_percent": {
+				Description: "Total CPU utilization in percent",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"load": {
+				Description: "The 1, 5 and 15 minute load average",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeFloat},
+			},
+		},
+	}
+}
+
+// dataSourceInfoSystemRead reads the resource usage of the Pi-hole host
+func dataSourceInfoSystemRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	info, err := pm.Client.Info().System(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"uptime":              int(info.Uptime.Seconds()),
+		"processes":           info.Processes,
+		"memory_total":        info.MemoryTotal,
+		"memory_used":         info.MemoryUsed,
+		"memory_available":    info.MemoryAvailable,
+		"memory_percent_used": info.MemoryPercentUsed,
+		"swap_total":          info.SwapTotal,
+		"swap_used":           info.SwapUsed,
+		"swap_percent_used":   info.SwapPercentUsed,
+		"cpu_cores":           info.CPUCores,
+		"cpu_percent":         info.CPUPercent,
+		"load":                info.Load,
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	setSnapshotID(d, "system")
+
+	return diags
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+const testAccInfoDataConfig = `
+	data "pihole_info_system" "this" {}
+
+	data "pihole_info_host" "this" {}
+
+	data "pihole_info_ftl" "this" {}
+
+	data "pihole_info_database" "this" {}
+
+	data "pihole_info_sensors" "this" {}
+`
+
+func TestAccInfoData(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInfoDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_info_system.this", "uptime"),
+					resource.TestCheckResourceAttr("data.pihole_info_system.this", "load.#", "3"),
+					resource.TestCheckResourceAttrSet("data.pihole_info_host.this", "hostname"),
+					resource.TestCheckResourceAttrSet("data.pihole_info_ftl.this", "pid"),
+					resource.TestCheckResourceAttrSet("data.pihole_info_ftl.this", "version"),
+					resource.TestCheckResourceAttrSet("data.pihole_info_database.this", "size"),
+					resource.TestCheckResourceAttrSet("data.pihole_info_sensors.this", "unit"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInfoDataValues(t *testing.T) {
+	testAccFake(t, func(srv *fake.Server) {
+		srv.SetInfo(fake.Info{
+			Uptime:      600,
+			MemoryTotal: 8000,
+			MemoryUsed:  4000,
+			Load:        []float64{1, 0.5, 0.25},
+
+			Hostname: "pihole-1",
+			Model:    "Raspberry Pi 5",
+
+			FTLPID:          99,
+			FTLUptimeMillis: 120000,
+			FTLVersion:      "v6.1",
+
+			DatabaseSize:      1 << 20,
+			EarliestTimestamp: 1704067200,
+			SQLiteVersion:     "3.46.0",
+
+			CPUTemp:  51.5,
+			HotLimit: 60,
+			Sensors: []fake.Sensor{{
+				Name:  "cpu_thermal",
+				Path:  "/sys/class/thermal/thermal_zone0",
+				Temps: []fake.SensorTemp{{Name: "temp1", Value: 51.5}},
+			}},
+		})
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInfoDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_info_system.this", "memory_percent_used", "50"),
+					resource.TestCheckResourceAttr("data.pihole_info_host.this", "model", "Raspberry Pi 5"),
+					resource.TestCheckResourceAttr("data.pihole_info_ftl.this", "uptime", "120"),
+					resource.TestCheckResourceAttr("data.pihole_info_ftl.this", "version", "v6.1"),
+					resource.TestCheckResourceAttr("data.pihole_info_database.this", "earliest_timestamp", "2024-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("data.pihole_info_sensors.this", "cpu_temp", "51.5"),
+					resource.TestCheckResourceAttr("data.pihole_info_sensors.this", "sensors.0.temps.0.name", "temp1"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// dataSourceNetworkDevices returns a schema resource for listing the devices in Pi-hole's network table
+func dataSourceNetworkDevices() *schema.Resource {
+	return &schema.Resource{
+		Description: "List the devices Pi-hole has seen on the network",
+		ReadContext: dataSourceNetworkDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"interface": {
+				Description: "Only include devices seen on this interface",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"seen_within": {
+				Description:      "Only include devices last seen within this duration, such as `24h`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateDuration(),
+			},
+			"not_seen_within": {
+				Description:      "Only include devices not seen within this duration, such as `720h`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateDuration(),
+			},
+			"devices": {
+				Description: "List of network devices",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Network table ID of the device",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"hwaddr": {
+							Description: "Hardware (MAC) address of the device",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"interface": {
+							Description: "Interface the device was seen on",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"vendor": {
+							Description: "Vendor derived from the hardware address",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"first_seen": {
+							Description: "When the device was first seen (RFC 3339)",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_query": {
+							Description: "When the device last sent a query (RFC 3339), empty if it never did",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"last_seen": {
+							Description: "When the device was last active (RFC 3339), either by sending a query or by being seen with one of its addresses",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"num_queries": {
+							Description: "Number of queries sent by the device",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"ips": {
+							Description: "Addresses the device has been seen with",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ip": {
+										Description: "IP address",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"name": {
+										Description: "Host name resolved for the address",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"last_seen": {
+										Description: "When the address was last seen (RFC 3339)",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+									"name_updated": {
+										Description: "When the host name was last updated (RFC 3339)",
+										Type:        schema.TypeString,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceNetworkDevicesRead lists the devices in Pi-hole's network table
+func dataSourceNetworkDevicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	filter := networkDeviceFilterFromData(d)
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	devices, err := pm.Client.Network().List(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	now := time.Now()
+	list := make([]map[string]interface{}, 0, len(devices))
+	idRef := fmt.Sprintf("%s|%s|%s|", filter.iface, filter.seenWithin, filter.notSeenWithin)
+
+	for _, device := range devices {
+		if !filter.match(device, now) {
+			continue
+		}
+
+		idRef = fmt.Sprintf("%s|%d|", idRef, device.ID)
+
+		ips := make([]map[string]interface{}, len(device.Addresses))
+		for i, a := range device.Addresses {
+			ips[i] = map[string]interface{}{
+				"ip":           a.IP,
+				"name":         a.Name,
+				"last_seen":    formatTimestamp(a.LastSeen),
+				"name_updated": formatTimestamp(a.NameUpdated),
+			}
+		}
+
+		list = append(list, map[string]interface{}{
+			"id":          device.ID,
+			"hwaddr":      device.HWAddr,
+			"interface":   device.Interface,
+			"vendor":      device.Vendor,
+			"first_seen":  formatTimestamp(device.FirstSeen),
+			"last_query":  formatTimestamp(device.LastQuery),
+			"last_seen":   formatTimestamp(device.LastSeen()),
+			"num_queries": device.NumQueries,
+			"ips":         ips,
+		})
+	}
+
+	if err := d.Set("devices", list); err != nil {
+		return diagFromErr(err)
+	}
+
+	hash := sha256.Sum256([]byte(idRef))
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+
+	return diags
+}
+
+// networkDeviceFilter selects network devices by interface and last-seen age.
+// Zero values match every device.
+type networkDeviceFilter struct {
+	iface         string
+	seenWithin    time.Duration
+	notSeenWithin time.Duration
+}
+
+// networkDeviceFilterFromData reads the filter attributes of d
+func networkDeviceFilterFromData(d *schema.ResourceData) networkDeviceFilter {
+	// Durations are checked by validateDuration
+	seenWithin, _ := time.ParseDuration(d.Get("seen_within").(string))
+	notSeenWithin, _ := time.ParseDuration(d.Get("not_seen_within").(string))
+
+	return networkDeviceFilter{
+		iface:         d.Get("interface").(string),
+		seenWithin:    seenWithin,
+		notSeenWithin: notSeenWithin,
+	}
+}
+
+// match reports whether device passes the filter at time now
+func (f networkDeviceFilter) match(device pihole.NetworkDevice, now time.Time) bool {
+	if f.iface != "" && device.Interface != f.iface {
+		return false
+	}
+
+	lastSeen := time.Unix(device.LastSeen(), 0)
+	if f.seenWithin > 0 && now.Sub(lastSeen) > f.seenWithin {
+		return false
+	}
+	if f.notSeenWithin > 0 && now.Sub(lastSeen) <= f.notSeenWithin {
+		return false
+	}
+
+	return true
+}
+
+// formatTimestamp formats a Unix timestamp from the API as RFC 3339.
+// Pi-hole reports timestamps it never recorded as 0, which is formatted as "".
+func formatTimestamp(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+func TestAccNetworkDevicesData(t *testing.T) {
+	now := time.Now().Unix()
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_network_devices" "all" {}
+
+					data "pihole_network_devices" "recent" {
+					  seen_within = "24h"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_network_devices.all", "devices.#"),
+					resource.TestCheckResourceAttrSet("data.pihole_network_devices.recent", "devices.#"),
+				),
+			},
+		},
+	}, func(srv *fake.Server) {
+		srv.AddDevice(fake.Device{
+			HWAddr:    "aa:bb:cc:dd:ee:01",
+			Interface: "eth0",
+			LastQuery: now,
+			Addresses: []fake.Address{{IP: "192.168.1.10", Name: "pi.lan", LastSeen: now}},
+		})
+		srv.AddDevice(fake.Device{HWAddr: "aa:bb:cc:dd:ee:02", Interface: "eth0"})
+	})
+}
+
+func TestNetworkDeviceFilter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	recent := pihole.NetworkDevice{Interface: "eth0", LastQuery: now.Add(-time.Hour).Unix()}
+	stale := pihole.NetworkDevice{
+		Interface: "wlan0",
+		Addresses: []pihole.NetworkAddress{{LastSeen: now.Add(-60 * 24 * time.Hour).Unix()}},
+	}
+
+	tests := []struct {
+		name   string
+		filter networkDeviceFilter
+		recent bool
+		stale  bool
+	}{
+		{name: "none", filter: networkDeviceFilter{}, recent: true, stale: true},
+		{name: "interface", filter: networkDeviceFilter{iface: "wlan0"}, recent: false, stale: true},
+		{name: "seen within", filter: networkDeviceFilter{seenWithin: 24 * time.Hour}, recent: true, stale: false},
+		{name: "not seen within", filter: networkDeviceFilter{notSeenWithin: 30 * 24 * time.Hour}, recent: false, stale: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.match(recent, now); got != tt.recent {
+				t.Errorf("recent device: got %v, want %v", got, tt.recent)
+			}
+			if got := tt.filter.match(stale, now); got != tt.stale {
+				t.Errorf("stale device: got %v, want %v", got, tt.stale)
+			}
+		})
+	}
+}
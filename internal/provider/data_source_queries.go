@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// maxQueryResults caps max_results so a data source cannot pull a whole query log into the state
+const maxQueryResults = 10000
+
+// dataSourceQueries returns a schema resource for reading Pi-hole's query log
+func dataSourceQueries() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read entries of Pi-hole's query log, newest first. " +
+			"The log changes continuously, so use the results for checks and outputs rather than resource arguments.",
+		ReadContext: dataSourceQueriesRead,
+		Schema: map[string]*schema.Schema{
+			"client_ip": {
+				Description: "Only include queries from this client IP address",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"client_name": {
+				Description: "Only include queries from this client host name",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"domain": {
+				Description: "Only include queries for this domain. May contain `*` wildcards.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"upstream": {
+				Description: "Only include queries answered by this upstream, such as `1.1.1.1#53`, `cache` or `blocklist`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"type": {
+				Description: "Only include queries of this record type, such as `A` or `AAAA`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description: "Only include queries with this status, such as `GRAVITY` or `FORWARDED`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"from": {
+				Description:      "Only include queries received at or after this time (RFC 3339)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			"until": {
+				Description:      "Only include queries received at or before this time (RFC 3339)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			"cursor": {
+				Description: "ID of the newest query to include. Queries received later are ignored, which keeps the results stable.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"max_results": {
+				Description:      fmt.Sprintf("Maximum number of queries to return, at most %d", maxQueryResults),
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, maxQueryResults)),
+			},
+			"truncated": {
+				Description: "Whether more queries matched than `max_results`",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"queries": {
+				Description: "Matching queries, newest first",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Query log ID of the query",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"time": {
+							Description: "When the query was received (RFC 3339)",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Record type of the query",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"domain": {
+							Description: "Queried domain",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cname": {
+							Description: "Domain in the CNAME chain that caused the query to be blocked, if any",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "How the query was handled, such as `GRAVITY` or `FORWARDED`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"client_ip": {
+							Description: "IP address of the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"client_name": {
+							Description: "Host name of the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"upstream": {
+							Description: "Upstream the query was forwarded to",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"dnssec": {
+							Description: "DNSSEC status of the reply",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"reply_type": {
+							Description: "Type of the reply, such as `IP` or `NXDOMAIN`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"reply_time": {
+							Description: "Time taken to reply in milliseconds",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceQueriesRead reads the matching entries of the query log
+func dataSourceQueriesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	filter := pihole.QueryFilter{
+		ClientIP:   d.Get("client_ip").(string),
+		ClientName: d.Get("client_name").(string),
+		Domain:     d.Get("domain").(string),
+		Upstream:   d.Get("upstream").(string),
+		Type:       d.Get("type").(string),
+		Status:     d.Get("status").(string),
+		Cursor:     d.Get("cursor").(int),
+	}
+
+	// Times are checked by validation.IsRFC3339Time
+	if v := d.Get("from").(string); v != "" {
+		from, _ := time.Parse(time.RFC3339, v)
+		filter.From = from.Unix()
+	}
+	if v := d.Get("until").(string); v != "" {
+		until, _ := time.Parse(time.RFC3339, v)
+		filter.Until = until.Unix()
+	}
+
+	maxResults := d.Get("max_results").(int)
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	list := make([]map[string]interface{}, 0)
+	truncated := false
+
+	err := pm.Client.Queries().Each(ctx, filter, func(q pihole.QueryLogEntry) bool {
+		if len(list) == maxResults {
+			truncated = true
+			return false
+		}
+
+		list = append(list, map[string]interface{}{
+			"id":          q.ID,
+			"time":        formatQueryTime(q.Time),
+			"type":        q.Type,
+			"domain":      q.Domain,
+			"cname":       q.CNAME,
+			"status":      q.Status,
+			"client_ip":   q.ClientIP,
+			"client_name": q.ClientName,
+			"upstream":    q.Upstream,
+			"dnssec":      q.DNSSEC,
+			"reply_type":  q.ReplyType,
+			"reply_time":  q.ReplyTime,
+		})
+		return true
+	})
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	if err := d.Set("queries", list); err != nil {
+		return diagFromErr(err)
+	}
+	if err := d.Set("truncated", truncated); err != nil {
+		return diagFromErr(err)
+	}
+
+	// The ID only depends on the arguments as the log changes constantly
+	idRef := fmt.Sprintf("%+v|%d", filter, maxResults)
+	hash := sha256.Sum256([]byte(idRef))
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+
+	return diags
+}
+
+// formatQueryTime formats a fractional Unix timestamp from the query log as RFC 3339
+func formatQueryTime(ts float64) string {
+	sec, frac := math.Modf(ts)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC().Format(time.RFC3339Nano)
+}
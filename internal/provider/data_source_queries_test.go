@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+const testAccQueriesDataConfig = `
+	data "pihole_queries" "recent" {
+	  max_results = 5
+	}
+
+	data "pihole_queries" "blocked" {
+	  status      = "GRAVITY"
+	  from        = "2023-11-14T22:15:00Z"
+	  max_results = 1000
+	}
+`
+
+func TestAccQueriesData(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueriesDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_queries.recent", "queries.#"),
+					resource.TestCheckResourceAttrSet("data.pihole_queries.blocked", "truncated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQueriesDataValues(t *testing.T) {
+	testAccFake(t, func(srv *fake.Server) {
+		for i := 0; i < 250; i++ {
+			status := "FORWARDED"
+			if i%5 == 0 {
+				status = "GRAVITY"
+			}
+			srv.AddQuery(fake.Query{
+				Time:     float64(1700000000+i) + 0.5,
+				Type:     "A",
+				Domain:   fmt.Sprintf("host-%d.example.com", i),
+				Status:   status,
+				ClientIP: "192.168.1.10",
+			})
+		}
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueriesDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_queries.recent", "queries.#", "5"),
+					resource.TestCheckResourceAttr("data.pihole_queries.recent", "truncated", "true"),
+					resource.TestCheckResourceAttr("data.pihole_queries.recent", "queries.0.domain", "host-249.example.com"),
+					resource.TestCheckResourceAttr("data.pihole_queries.recent", "queries.0.time", "2023-11-14T22:17:29.5Z"),
+					resource.TestCheckResourceAttr("data.pihole_queries.blocked", "queries.#", "30"),
+					resource.TestCheckResourceAttr("data.pihole_queries.blocked", "truncated", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceStatsSummary returns a schema resource for Pi-hole's query statistics summary
+func dataSourceStatsSummary() *schema.Resource {
+	return &schema.Resource{
+		Description: "Summary of the queries Pi-hole has answered. " +
+			"The values change on every read, so use them for outputs and dashboards rather than resource arguments.",
+		ReadContext: dataSourceStatsSummaryRead,
+		Schema: map[string]*schema.Schema{
+			"total_queries": {
+				Description: "Number of queries received",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"blocked_queries": {
+				Description: "Number of queries blocked",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"percent_blocked": {
+				Description: "Percentage of queries blocked",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"unique_domains": {
+				Description: "Number of distinct domains queried",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"forwarded_queries": {
+				Description: "Number of queries forwarded to an upstream server",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"cached_queries": {
+				Description: "Number of queries answered from the cache",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"query_frequency": {
+				Description: "Queries per second",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"query_types": {
+				Description: "Number of queries by record type, such as `A` or `AAAA`",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"active_clients": {
+				Description: "Number of clients that sent queries recently",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"total_clients": {
+				Description: "Number of clients ever seen",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"domains_being_blocked": {
+				Description: "Number of domains on the gravity blocklist",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"gravity_last_update": {
+				Description: "When the gravity blocklist was last updated (RFC 3339)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceStatsSummaryRead reads the query statistics summary
+func dataSourceStatsSummaryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	summary, err := pm.Client.Stats().Summary(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"total_queries":         summary.TotalQueries,
+		"blocked_queries":       summary.BlockedQueries,
+		"percent_blocked":       summary.PercentBlocked,
+		"unique_domains":        summary.UniqueDomains,
+		"forwarded_queries":     summary.ForwardedQueries,
+		"cached_queries":        summary.CachedQueries,
+		"query_frequency":       summary.QueryFrequency,
+		"query_types":           summary.QueryTypes,
+		"active_clients":        summary.ActiveClients,
+		"total_clients":         summary.TotalClients,
+		"domains_being_blocked": summary.DomainsBeingBlocked,
+		"gravity_last_update":   formatTimestamp(summary.GravityLastUpdate),
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diagFromErr(err)
+		}
+	}
+
+	setSnapshotID(d, "summary")
+
+	return diags
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+const testAccStatsDataConfig = `
+	data "pihole_stats_summary" "this" {}
+
+	data "pihole_stats_top_domains" "blocked" {
+	  blocked = true
+	  limit   = 5
+	}
+
+	data "pihole_stats_top_clients" "this" {}
+
+	data "pihole_stats_upstreams" "this" {}
+`
+
+func TestAccStatsData(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStatsDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_stats_summary.this", "total_queries"),
+					resource.TestCheckResourceAttr("data.pihole_stats_top_domains.blocked", "id", "true|5"),
+					resource.TestCheckResourceAttrSet("data.pihole_stats_top_domains.blocked", "domains.#"),
+					resource.TestCheckResourceAttrSet("data.pihole_stats_top_clients.this", "clients.#"),
+					resource.TestCheckResourceAttrSet("data.pihole_stats_upstreams.this", "upstreams.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStatsDataValues(t *testing.T) {
+	testAccFake(t, func(srv *fake.Server) {
+		srv.SetStats(fake.Stats{
+			TotalQueries:   200,
+			BlockedQueries: 50,
+			QueryTypes:     map[string]int{"A": 150, "AAAA": 50},
+			TopBlocked:     []fake.DomainCount{{Domain: "ads.example.com", Count: 40}},
+			TopClients:     []fake.ClientCount{{IP: "192.168.1.10", Name: "laptop", Count: 120}},
+			Upstreams:      []fake.Upstream{{IP: "1.1.1.1", Name: "one.one.one.one", Port: 53, Count: 100}},
+		})
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStatsDataConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_stats_summary.this", "percent_blocked", "25"),
+					resource.TestCheckResourceAttr("data.pihole_stats_summary.this", "query_types.A", "150"),
+					resource.TestCheckResourceAttr("data.pihole_stats_top_domains.blocked", "domains.0.domain", "ads.example.com"),
+					resource.TestCheckResourceAttr("data.pihole_stats_top_clients.this", "clients.0.name", "laptop"),
+					resource.TestCheckResourceAttr("data.pihole_stats_upstreams.this", "upstreams.0.port", "53"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceStatsTopClients returns a schema resource for the Pi-hole clients sending the most queries
+func dataSourceStatsTopClients() *schema.Resource {
+	return &schema.Resource{
+		Description: "The clients sending the most queries, or the most blocked queries. " +
+			"The values change on every read, so use them for outputs and dashboards rather than resource arguments.",
+		ReadContext: dataSourceStatsTopClientsRead,
+		Schema: map[string]*schema.Schema{
+			"blocked": {
+				Description: "Rank clients by blocked queries instead of all queries",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"limit": {
+				Description:      "Number of clients to list",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"clients": {
+				Description: "Clients ordered by number of queries, highest first",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Description: "IP address of the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Host name of the client",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"count": {
+							Description: "Number of queries sent by the client",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceStatsTopClientsRead reads the clients sending the most queries
+func dataSourceStatsTopClientsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	blocked := d.Get("blocked").(bool)
+	limit := d.Get("limit").(int)
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	clients, err := pm.Client.Stats().TopClients(ctx, blocked, limit)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	list := make([]map[string]interface{}, len(clients))
+	for i, c := range clients {
+		list[i] = map[string]interface{}{
+			"ip":    c.IP,
+			"name":  c.Name,
+			"count": c.Count,
+		}
+	}
+
+	if err := d.Set("clients", list); err != nil {
+		return diagFromErr(err)
+	}
+
+	setSnapshotID(d, fmt.Sprintf("%t|%d", blocked, limit))
+
+	return diags
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceStatsTopDomains returns a schema resource for Pi-hole's most queried domains
+func dataSourceStatsTopDomains() *schema.Resource {
+	return &schema.Resource{
+		Description: "The domains queried most often, either permitted or blocked. " +
+			"The values change on every read, so use them for outputs and dashboards rather than resource arguments.",
+		ReadContext: dataSourceStatsTopDomainsRead,
+		Schema: map[string]*schema.Schema{
+			"blocked": {
+				Description: "List blocked domains instead of permitted ones",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"limit": {
+				Description:      "Number of domains to list",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"domains": {
+				Description: "Domains ordered by number of queries, highest first",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Description: "Queried domain",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"count": {
+							Description: "Number of queries for the domain",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceStatsTopDomainsRead reads the most queried domains
+func dataSourceStatsTopDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	blocked := d.Get("blocked").(bool)
+	limit := d.Get("limit").(int)
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	domains, err := pm.Client.Stats().TopDomains(ctx, blocked, limit)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	list := make([]map[string]interface{}, len(domains))
+	for i, domain := range domains {
+		list[i] = map[string]interface{}{
+			"domain": domain.Domain,
+			"count":  domain.Count,
+		}
+	}
+
+	if err := d.Set("domains", list); err != nil {
+		return diagFromErr(err)
+	}
+
+	setSnapshotID(d, fmt.Sprintf("%t|%d", blocked, limit))
+
+	return diags
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceStatsUpstreams returns a schema resource for the destinations Pi-hole answered queries from
+func dataSourceStatsUpstreams() *schema.Resource {
+	return &schema.Resource{
+		Description: "The upstream servers Pi-hole forwarded queries to, plus the `blocklist` and `cache` pseudo upstreams. " +
+			"The values change on every read, so use them for outputs and dashboards rather than resource arguments.",
+		ReadContext: dataSourceStatsUpstreamsRead,
+		Schema: map[string]*schema.Schema{
+			"upstreams": {
+				Description: "Upstream destinations",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Description: "IP address of the upstream",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Host name of the upstream",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"port": {
+							Description: "Port of the upstream, `-1` for pseudo upstreams",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"count": {
+							Description: "Number of queries answered by the upstream",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"response_time": {
+							Description: "Average response time in milliseconds",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+						"response_variance": {
+							Description: "Variance of the response time in milliseconds",
+							Type:        schema.TypeFloat,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceStatsUpstreamsRead reads the upstream destinations
+func dataSourceStatsUpstreamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	pm.RLock()
+	defer pm.RUnlock()
+
+	upstreams, err := pm.Client.Stats().Upstreams(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	list := make([]map[string]interface{}, len(upstreams))
+	for i, u := range upstreams {
+		list[i] = map[string]interface{}{
+			"ip":                u.IP,
+			"name":              u.Name,
+			"port":              u.Port,
+			"count":             u.Count,
+			"response_time":     u.ResponseTime,
+			"response_variance": u.ResponseVariance,
+		}
+	}
+
+	if err := d.Set("upstreams", list); err != nil {
+		return diagFromErr(err)
+	}
+
+	setSnapshotID(d, "upstreams")
+
+	return diags
+}
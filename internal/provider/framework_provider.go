@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poindexter12/terraform-provider-pihole/internal/version"
+)
+
+// ProviderServer returns the factory of the provider's plugin server. It
+// serves the SDKv2 provider and the framework provider side by side, so
+// resources can be ported to the framework one at a time.
+func ProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return newMuxServer(ctx, Provider())
+}
+
+// newMuxServer combines sdk and a framework provider sharing its meta
+func newMuxServer(ctx context.Context, sdk *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	server, err := tf5muxserver.NewMuxServer(ctx,
+		// The mux server configures its servers in order, so the SDKv2
+		// provider has created the client by the time the framework provider
+		// is configured
+		sdk.GRPCProvider,
+		providerserver.NewProtocol5(&frameworkProvider{sdk: sdk}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.ProviderServer, nil
+}
+
+// frameworkProvider serves the resources written with
+// terraform-plugin-framework
+type frameworkProvider struct {
+	// sdk is the SDKv2 provider served alongside. Its meta is shared so both
+	// providers use the same client and Pi-hole session.
+	sdk *schema.Provider
+}
+
+var _ fwprovider.ProviderWithFunctions = &frameworkProvider{}
+
+// Metadata returns the provider type name
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "pihole"
+	resp.Version = version.ProviderVersion
+}
+
+// Schema returns the provider configuration schema, which is the SDKv2
+// provider's as the mux server requires them to be identical
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = frameworkProviderSchema(p.sdk)
+}
+
+// Configure hands the SDKv2 provider's meta to the framework resources
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	pm, ok := p.sdk.Meta().(*ProviderMeta)
+	if !ok {
+		resp.Diagnostics.AddError("Provider not configured", "The Pi-hole client was not created before the framework provider was configured.")
+		return
+	}
+
+	resp.ResourceData = pm
+	resp.DataSourceData = pm
+}
+
+// Resources returns the resources implemented with the framework
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newClientResource,
+		newCNAMERecordResource,
+		newDNSRecordResource,
+	}
+}
+
+// DataSources returns the data sources implemented with the framework
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newClientDataSource,
+		newCNAMERecordDataSource,
+		newDNSRecordDataSource,
+	}
+}
+
+// Functions returns the provider-defined functions, which need Terraform 1.8
+// or later
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newIsValidDomainFunction,
+		newNormalizeDomainFunction,
+		newParseCNAMEListFunction,
+		newParseHostsFunction,
+	}
+}
+
+// frameworkProviderSchema converts the configuration schema of the SDKv2
+// provider into a framework schema
+func frameworkProviderSchema(sdk *schema.Provider) fwschema.Schema {
+	attributes := make(map[string]fwschema.Attribute, len(sdk.Schema))
+	for name, s := range sdk.Schema {
+		switch s.Type {
+		case schema.TypeString:
+			attributes[name] = fwschema.StringAttribute{Description: s.Description, Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive}
+		case schema.TypeBool:
+			attributes[name] = fwschema.BoolAttribute{Description: s.Description, Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive}
+		case schema.TypeInt:
+			attributes[name] = fwschema.Int64Attribute{Description: s.Description, Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive}
+		case schema.TypeMap:
+			// Maps of strings are the only maps the SDKv2 provider declares
+			attributes[name] = fwschema.MapAttribute{ElementType: types.StringType, Description: s.Description, Optional: s.Optional, Required: s.Required, Sensitive: s.Sensitive}
+		default:
+			panic(fmt.Sprintf("provider attribute %q has unsupported type %s", name, s.Type))
+		}
+	}
+
+	return fwschema.Schema{Attributes: attributes}
+}
+
+// providerMetaFromData extracts the ProviderMeta passed to a framework
+// resource. It is nil before the provider is configured, e.g. during validation.
+func providerMetaFromData(data any) (*ProviderMeta, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	if data == nil {
+		return nil, diags
+	}
+
+	pm, ok := data.(*ProviderMeta)
+	if !ok {
+		diags.AddError("Unexpected provider data", "could not load provider metadata")
+	}
+	return pm, diags
+}
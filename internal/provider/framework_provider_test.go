@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+// sdkPrivateState is the private state the SDKv2 resources stored, holding
+// their default timeouts
+const sdkPrivateState = `{"e2bfb730-ecaa-11e6-8f88-34363bc7c4c0":{"create":300000000000,"delete":300000000000,"read":300000000000},"schema_version":"0"}`
+
+func TestMuxServerSchema(t *testing.T) {
+	server, err := newMuxServer(context.Background(), Provider())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("%s: %s", d.Summary, d.Detail)
+	}
+
+	for _, name := range []string{"pihole_client", "pihole_cname_record", "pihole_dns_record", "pihole_dhcp_lease_removal"} {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("resource %s is not served", name)
+		}
+	}
+}
+
+// TestPortedResourcesReadSDKv2State checks that state written by the SDKv2
+// implementations of the ported resources is read back unchanged and plans
+// no changes
+func TestPortedResourcesReadSDKv2State(t *testing.T) {
+	cases := []struct {
+		typeName string
+		state    string
+		seed     func(*fake.Server)
+
+		// added returns the attributes added since the port, set when the
+		// state is first read
+		added func(*fake.Server) map[string]tftypes.Value
+	}{
+		{
+			typeName: "pihole_dns_record",
+			state:    `{"domain":"a.lan","force":false,"id":"a.lan","ip":"10.0.0.1","timeouts":null}`,
+			seed:     func(s *fake.Server) { s.SetHosts("10.0.0.1 a.lan") },
+		},
+		{
+			typeName: "pihole_cname_record",
+			state:    `{"domain":"b.lan","force":false,"id":"b.lan","target":"a.lan","timeouts":null}`,
+			seed:     func(s *fake.Server) { s.SetCNAMEs("b.lan,a.lan") },
+		},
+		{
+			typeName: "pihole_client",
+			state:    `{"client":"10.0.0.5","comment":"printer","id":"10.0.0.5","timeouts":null}`,
+			seed:     func(s *fake.Server) { s.AddClient("10.0.0.5", "printer") },
+			added: func(s *fake.Server) map[string]tftypes.Value {
+				c := s.Clients()[0]
+				return map[string]tftypes.Value{
+					"type":          tftypes.NewValue(tftypes.String, "ipv4"),
+					"database_id":   tftypes.NewValue(tftypes.Number, c.ID),
+					"name":          tftypes.NewValue(tftypes.String, ""),
+					"vendor":        tftypes.NewValue(tftypes.String, ""),
+					"groups":        tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{tftypes.NewValue(tftypes.Number, 0)}),
+					"date_added":    tftypes.NewValue(tftypes.String, formatTimestamp(c.DateAdded)),
+					"date_modified": tftypes.NewValue(tftypes.String, formatTimestamp(c.DateModified)),
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.typeName, func(t *testing.T) {
+			ctx := context.Background()
+
+			srv := fake.NewServer()
+			defer srv.Close()
+			tc.seed(srv)
+
+			var added map[string]tftypes.Value
+			if tc.added != nil {
+				added = tc.added(srv)
+			}
+
+			t.Setenv("__PIHOLE_SESSION_ID", "")
+
+			factory, err := newMuxServer(ctx, Provider())
+			if err != nil {
+				t.Fatal(err)
+			}
+			server := factory()
+
+			schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			configureServer(t, server, schemas.Provider, srv.URL)
+
+			typ := schemas.ResourceSchemas[tc.typeName].ValueType()
+			prior, err := tfprotov5.RawState{JSON: []byte(tc.state)}.Unmarshal(typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prior, err = tftypes.Transform(prior, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+				for name, value := range added {
+					if p.Equal(tftypes.NewAttributePath().WithAttributeName(name)) {
+						return value, nil
+					}
+				}
+				return v, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			upgraded, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+				TypeName: tc.typeName,
+				Version:  0,
+				RawState: &tfprotov5.RawState{JSON: []byte(tc.state)},
+			})
+			checkDiagnostics(t, "UpgradeResourceState", upgraded.Diagnostics, err)
+
+			read, err := server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+				TypeName:     tc.typeName,
+				CurrentState: upgraded.UpgradedState,
+				Private:      []byte(sdkPrivateState),
+			})
+			checkDiagnostics(t, "ReadResource", read.Diagnostics, err)
+
+			state, err := read.NewState.Unmarshal(typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff, _ := prior.Diff(state); len(diff) > 0 {
+				t.Fatalf("state changed when read: %v", diff)
+			}
+
+			// The configuration matching the state leaves out computed values
+			config, err := tftypes.Transform(state, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+				if p.Equal(tftypes.NewAttributePath().WithAttributeName("id")) {
+					return tftypes.NewValue(tftypes.String, nil), nil
+				}
+				for name, value := range added {
+					if p.Equal(tftypes.NewAttributePath().WithAttributeName(name)) {
+						return tftypes.NewValue(value.Type(), nil), nil
+					}
+				}
+				return v, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			configValue, err := tfprotov5.NewDynamicValue(typ, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			plan, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+				TypeName:         tc.typeName,
+				PriorState:       read.NewState,
+				ProposedNewState: read.NewState,
+				Config:           &configValue,
+				PriorPrivate:     read.Private,
+			})
+			checkDiagnostics(t, "PlanResourceChange", plan.Diagnostics, err)
+
+			if len(plan.RequiresReplace) > 0 {
+				t.Errorf("plan replaces the resource because of %v", plan.RequiresReplace)
+			}
+			planned, err := plan.PlannedState.Unmarshal(typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff, _ := prior.Diff(planned); len(diff) > 0 {
+				t.Errorf("plan changes the state: %v", diff)
+			}
+		})
+	}
+}
+
+// configureServer configures server to use the fake Pi-hole at url
+func configureServer(t *testing.T, server tfprotov5.ProviderServer, providerSchema *tfprotov5.Schema, url string) {
+	t.Helper()
+
+	typ := providerSchema.ValueType().(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["url"] = tftypes.NewValue(tftypes.String, url)
+	values["password"] = tftypes.NewValue(tftypes.String, fake.DefaultPassword)
+
+	config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+		TerraformVersion: "1.5.7",
+		Config:           &config,
+	})
+	checkDiagnostics(t, "ConfigureProvider", resp.Diagnostics, err)
+}
+
+// checkDiagnostics fails the test if a provider RPC failed
+func checkDiagnostics(t *testing.T, rpc string, diags []*tfprotov5.Diagnostic, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("%s: %s", rpc, err)
+	}
+	for _, d := range diags {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", rpc, d.Summary, d.Detail)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// isValidDomainFunction validates domain names
+type isValidDomainFunction struct{}
+
+var _ function.Function = &isValidDomainFunction{}
+
+// newIsValidDomainFunction returns the is_valid_domain function
+func newIsValidDomainFunction() function.Function {
+	return &isValidDomainFunction{}
+}
+
+// Metadata returns the function name
+func (f *isValidDomainFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_domain"
+}
+
+// Definition returns the function signature
+func (f *isValidDomainFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check whether a domain name is valid",
+		Description: "Returns true if the domain is accepted by the domain attributes of pihole_dns_record and pihole_cname_record.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "domain",
+				Description: "Domain name to check",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run validates the domain
+func (f *isValidDomainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+	resp.Error = req.Arguments.Get(ctx, &domain)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, pihole.ValidDomain(domain))
+}
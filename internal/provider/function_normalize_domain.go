@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// normalizeDomainFunction normalizes domain names
+type normalizeDomainFunction struct{}
+
+var _ function.Function = &normalizeDomainFunction{}
+
+// newNormalizeDomainFunction returns the normalize_domain function
+func newNormalizeDomainFunction() function.Function {
+	return &normalizeDomainFunction{}
+}
+
+// Metadata returns the function name
+func (f *normalizeDomainFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_domain"
+}
+
+// Definition returns the function signature
+func (f *normalizeDomainFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize a domain name",
+		Description: "Returns the domain in lower case, without surrounding whitespace or a trailing dot.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "domain",
+				Description: "Domain name to normalize",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the domain
+func (f *normalizeDomainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+	resp.Error = req.Arguments.Get(ctx, &domain)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, pihole.NormalizeDomain(domain))
+}
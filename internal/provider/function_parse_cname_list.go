@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// parseCNAMEListFunction parses CNAME lists into CNAME records
+type parseCNAMEListFunction struct{}
+
+// cnameListRecordModel is a record returned by parse_cname_list
+type cnameListRecordModel struct {
+	Domain string `tfsdk:"domain"`
+	Target string `tfsdk:"target"`
+}
+
+var _ function.Function = &parseCNAMEListFunction{}
+
+// newParseCNAMEListFunction returns the parse_cname_list function
+func newParseCNAMEListFunction() function.Function {
+	return &parseCNAMEListFunction{}
+}
+
+// Metadata returns the function name
+func (f *parseCNAMEListFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_cname_list"
+}
+
+// Definition returns the function signature
+func (f *parseCNAMEListFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a CNAME list into CNAME records",
+		Description: "Parses lines of the form domain[,domain...],target[,ttl] into a list of objects with domain and target attributes, " +
+			"one for every domain on a line. The cname= prefix of dnsmasq configuration files such as Pi-hole v5's 05-pihole-custom-cname.conf is accepted. " +
+			"Blank lines and # comments are skipped. Lines are parsed exactly as Pi-hole's dns.cnameRecords entries are read by pihole_cname_records.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "content",
+				Description: "Contents of the CNAME list",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"domain": types.StringType,
+				"target": types.StringType,
+			}},
+		},
+	}
+}
+
+// Run parses the CNAME list, failing on invalid domains
+func (f *parseCNAMEListFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+	resp.Error = req.Arguments.Get(ctx, &content)
+	if resp.Error != nil {
+		return
+	}
+
+	records := []cnameListRecordModel{}
+	for i, line := range strings.Split(content, "\n") {
+		for _, record := range pihole.ParseCNAMELine(line) {
+			for _, domain := range []string{record.Domain, record.Target} {
+				if !pihole.ValidDomain(domain) {
+					resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("line %d: %q is not a valid domain", i+1, domain))
+					return
+				}
+			}
+
+			records = append(records, cnameListRecordModel{Domain: record.Domain, Target: record.Target})
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, records)
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// parseHostsFunction parses hosts files into DNS records
+type parseHostsFunction struct{}
+
+// hostsRecordModel is a record returned by parse_hosts
+type hostsRecordModel struct {
+	Domain string `tfsdk:"domain"`
+	IP     string `tfsdk:"ip"`
+}
+
+var _ function.Function = &parseHostsFunction{}
+
+// newParseHostsFunction returns the parse_hosts function
+func newParseHostsFunction() function.Function {
+	return &parseHostsFunction{}
+}
+
+// Metadata returns the function name
+func (f *parseHostsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_hosts"
+}
+
+// Definition returns the function signature
+func (f *parseHostsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a hosts file into DNS records",
+		Description: "Parses the contents of a hosts file, such as /etc/hosts or a Pi-hole v5 custom.list export, " +
+			"into a list of objects with domain and ip attributes, one for every name on a line. " +
+			"Blank lines and # comments are skipped. Lines are parsed exactly as Pi-hole's dns.hosts entries are read by pihole_dns_records.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "content",
+				Description: "Contents of the hosts file",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"domain": types.StringType,
+				"ip":     types.StringType,
+			}},
+		},
+	}
+}
+
+// Run parses the hosts file, failing on invalid addresses and domains
+func (f *parseHostsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+	resp.Error = req.Arguments.Get(ctx, &content)
+	if resp.Error != nil {
+		return
+	}
+
+	records := []hostsRecordModel{}
+	for i, line := range strings.Split(content, "\n") {
+		for _, record := range pihole.ParseHostsLine(line) {
+			if net.ParseIP(record.IP) == nil {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("line %d: %q is not a valid IP address", i+1, record.IP))
+				return
+			}
+			if !pihole.ValidDomain(record.Domain) {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("line %d: %q is not a valid domain", i+1, record.Domain))
+				return
+			}
+
+			records = append(records, hostsRecordModel{Domain: record.Domain, IP: record.IP})
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, records)
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Terraform 1.8 is needed to call functions from configuration, so the tests
+// call them through the provider server
+func TestFunctions(t *testing.T) {
+	hostsType := tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"domain": tftypes.String, "ip": tftypes.String}}}
+	cnameType := tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"domain": tftypes.String, "target": tftypes.String}}}
+
+	hostsRecord := func(domain, ip string) tftypes.Value {
+		return tftypes.NewValue(hostsType.ElementType, map[string]tftypes.Value{
+			"domain": tftypes.NewValue(tftypes.String, domain),
+			"ip":     tftypes.NewValue(tftypes.String, ip),
+		})
+	}
+	cnameRecord := func(domain, target string) tftypes.Value {
+		return tftypes.NewValue(cnameType.ElementType, map[string]tftypes.Value{
+			"domain": tftypes.NewValue(tftypes.String, domain),
+			"target": tftypes.NewValue(tftypes.String, target),
+		})
+	}
+
+	cases := []struct {
+		name     string
+		argument string
+		expected tftypes.Value
+		err      string
+	}{
+		{
+			name:     "parse_hosts",
+			argument: "# Local hosts\n10.0.0.1 a.lan b.lan\n\nfd00::1\tc.lan # printer\n",
+			expected: tftypes.NewValue(hostsType, []tftypes.Value{hostsRecord("a.lan", "10.0.0.1"), hostsRecord("b.lan", "10.0.0.1"), hostsRecord("c.lan", "fd00::1")}),
+		},
+		{
+			name:     "parse_hosts",
+			argument: "",
+			expected: tftypes.NewValue(hostsType, []tftypes.Value{}),
+		},
+		{
+			name:     "parse_hosts",
+			argument: "10.0.0.1 a.lan\n10.0.0.300 b.lan\n",
+			err:      `line 2: "10.0.0.300" is not a valid IP address`,
+		},
+		{
+			name:     "parse_hosts",
+			argument: "10.0.0.1 -a.lan\n",
+			err:      `line 1: "-a.lan" is not a valid domain`,
+		},
+		{
+			name:     "parse_cname_list",
+			argument: "b.lan,a.lan\ncname=c.lan,d.lan,a.lan,300\n",
+			expected: tftypes.NewValue(cnameType, []tftypes.Value{cnameRecord("b.lan", "a.lan"), cnameRecord("c.lan", "a.lan"), cnameRecord("d.lan", "a.lan")}),
+		},
+		{
+			name:     "parse_cname_list",
+			argument: "b.lan,a.lan\n\nb.lan,a lan\n",
+			err:      `line 3: "a lan" is not a valid domain`,
+		},
+		{
+			name:     "normalize_domain",
+			argument: " Host.LAN. ",
+			expected: tftypes.NewValue(tftypes.String, "host.lan"),
+		},
+		{
+			name:     "is_valid_domain",
+			argument: "host.lan",
+			expected: tftypes.NewValue(tftypes.Bool, true),
+		},
+		{
+			name:     "is_valid_domain",
+			argument: "host.lan.",
+			expected: tftypes.NewValue(tftypes.Bool, false),
+		},
+	}
+
+	factory, err := newMuxServer(context.Background(), Provider())
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := factory()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			argument, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, tc.argument))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := server.CallFunction(context.Background(), &tfprotov5.CallFunctionRequest{
+				Name:      tc.name,
+				Arguments: []*tfprotov5.DynamicValue{&argument},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.err != "" {
+				if resp.Error == nil || !strings.Contains(resp.Error.Text, tc.err) {
+					t.Fatalf("expected error %q, got %v", tc.err, resp.Error)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatal(resp.Error.Text)
+			}
+
+			result, err := resp.Result.Unmarshal(tc.expected.Type())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Equal(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
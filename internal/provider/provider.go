@@ -2,12 +2,16 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 	"github.com/poindexter12/terraform-provider-pihole/internal/version"
 )
@@ -25,7 +29,7 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_URL", "http://pi.hole"),
-				Description: "URL where Pi-hole is deployed",
+				Description: "URL where Pi-hole is deployed. It may include the path prefix of a reverse proxy, such as `https://proxy.example.com/pihole`.",
 			},
 			"ca_file": {
 				Type:        schema.TypeString,
@@ -33,24 +37,156 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_CA_FILE", nil),
 				Description: "Path to a CA certificate file for TLS verification",
 			},
+			"ca_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_CA_PEM", nil),
+				Description: "PEM encoded CA certificates for TLS verification, like `ca_file` but inline. The certificates of both are trusted if both are set.",
+			},
+			"client_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_CLIENT_CERT_FILE", nil),
+				ConflictsWith: []string{"client_cert_pem"},
+				Description:   "Path to a PEM encoded client certificate for mutual TLS. Requires `client_key_file` or `client_key_pem`.",
+			},
+			"client_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_CLIENT_CERT_PEM", nil),
+				ConflictsWith: []string{"client_cert_file"},
+				Description:   "PEM encoded client certificate for mutual TLS, like `client_cert_file` but inline",
+			},
+			"client_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_CLIENT_KEY_FILE", nil),
+				ConflictsWith: []string{"client_key_pem"},
+				Description:   "Path to the PEM encoded private key of the client certificate",
+			},
+			"client_key_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_CLIENT_KEY_PEM", nil),
+				ConflictsWith: []string{"client_key_file"},
+				Description:   "PEM encoded private key of the client certificate, like `client_key_file` but inline",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_TLS_SERVER_NAME", nil),
+				Description: "Host name to verify the server certificate against instead of the host in `url`, e.g. when connecting by IP address",
+			},
 			"insecure_skip_verify": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 				Description: "Skip TLS certificate verification. WARNING: This is insecure and should only be used for testing or in trusted networks with self-signed certificates.",
 			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Headers sent with every request, such as the service token of an authenticating reverse proxy in front of Pi-hole",
+			},
+			"basic_auth_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_BASIC_AUTH_USERNAME", nil),
+				Description: "Username for HTTP basic authentication with a reverse proxy in front of Pi-hole",
+			},
+			"basic_auth_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_BASIC_AUTH_PASSWORD", nil),
+				Description: "Password for HTTP basic authentication with a reverse proxy in front of Pi-hole",
+			},
+			"proxy_url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("PIHOLE_PROXY_URL", nil),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithScheme([]string{"http", "https", "socks5", "socks5h"})),
+				Description:      "URL of an HTTP, HTTPS or SOCKS5 proxy to connect to Pi-hole through, such as `socks5://localhost:1080`. Defaults to the proxy set in the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+			},
+			"request_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "60s",
+				ValidateDiagFunc: validateDuration(),
+				Description:      "Timeout for each request to the Pi-hole API, as a duration such as `30s`. `0s` disables the timeout.",
+			},
+			"max_retries": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          pihole.DefaultRetryPolicy.MaxRetries,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Number of times a failed Pi-hole API request is retried. Applies to connection errors, `429` and `5xx` responses and \"item already present\" errors for records that are not actually present.",
+			},
+			"retry_wait_min": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          pihole.DefaultRetryPolicy.WaitMin.String(),
+				ValidateDiagFunc: validateDuration(),
+				Description:      "Backoff before the first retry, as a duration such as `200ms`. The backoff doubles on every further retry. A `Retry-After` header on `429` and `503` responses takes precedence.",
+			},
+			"retry_wait_max": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          pihole.DefaultRetryPolicy.WaitMax.String(),
+				ValidateDiagFunc: validateDuration(),
+				Description:      "Maximum backoff between retries, as a duration such as `30s`.",
+			},
+			"reap_stale_sessions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Revoke the Pi-hole API sessions left behind by earlier runs that crashed before logging out, whenever the provider logs in. Only sessions of the provider, of any version, that have been idle for `stale_session_age` are revoked, and a warning lists them. Orphaned sessions otherwise hold API seats until they time out, and logins fail once `webserver.api.max_sessions` are in use. When a login fails for lack of seats, the provider reaps with a session that an earlier run on this machine left behind and logs in again; it records its sessions in the user cache directory for this.",
+			},
+			"stale_session_age": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          pihole.DefaultStaleSessionAge.String(),
+				ValidateDiagFunc: validateDuration(),
+				Description:      "How long a session must have been idle before `reap_stale_sessions` revokes it, as a duration such as `25m`. Keep it above the longest time a run goes without calling the API, so that a run in progress never loses its session; the default is just under Pi-hole's default `webserver.session.timeout` of 30 minutes.",
+			},
+			"consistency_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "30s",
+				ValidateDiagFunc: validateDuration(),
+				Description:      "How long DNS and CNAME record writes wait for the change to be visible in Pi-hole before failing, as a duration such as `30s`. `0s` disables the check.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"pihole_clients":       dataSourceClients(),
-			"pihole_cname_records": dataSourceCNAMERecords(),
-			"pihole_dns_records":   dataSourceDNSRecords(),
+			"pihole_clients":           dataSourceClients(),
+			"pihole_cname_records":     dataSourceCNAMERecords(),
+			"pihole_dhcp_leases":       dataSourceDHCPLeases(),
+			"pihole_dns_records":       dataSourceDNSRecords(),
+			"pihole_domain_search":     dataSourceDomainSearch(),
+			"pihole_info_database":     dataSourceInfoDatabase(),
+			"pihole_info_ftl":          dataSourceInfoFTL(),
+			"pihole_info_host":         dataSourceInfoHost(),
+			"pihole_info_sensors":      dataSourceInfoSensors(),
+			"pihole_info_system":       dataSourceInfoSystem(),
+			"pihole_network_devices":   dataSourceNetworkDevices(),
+			"pihole_queries":           dataSourceQueries(),
+			"pihole_stats_summary":     dataSourceStatsSummary(),
+			"pihole_stats_top_clients": dataSourceStatsTopClients(),
+			"pihole_stats_top_domains": dataSourceStatsTopDomains(),
+			"pihole_stats_upstreams":   dataSourceStatsUpstreams(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"pihole_client":       resourceClient(),
-			"pihole_cname_record": resourceCNAMERecord(),
-			"pihole_dns_record":   resourceDNSRecord(),
+			"pihole_dhcp_lease_removal":     resourceDHCPLeaseRemoval(),
+			"pihole_flush_arp":              resourceFlushARP(),
+			"pihole_flush_logs":             resourceFlushLogs(),
+			"pihole_flush_network":          resourceFlushNetwork(),
+			"pihole_network_device_cleanup": resourceNetworkDeviceCleanup(),
+			"pihole_restart_dns":            resourceRestartDNS(),
 		},
 	}
 
@@ -65,17 +201,30 @@ func configure(version string, provider *schema.Provider) func(ctx context.Conte
 		// Check if a session ID was passed in externally (for testing or session reuse)
 		externalSessionID := os.Getenv("__PIHOLE_SESSION_ID")
 
-		piholeClient, err := Config{
-			Password:           d.Get("password").(string),
-			URL:                d.Get("url").(string),
-			UserAgent:          provider.UserAgent("terraform-provider-pihole", version),
-			CAFile:             d.Get("ca_file").(string),
-			InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
-			SessionID:          externalSessionID,
-		}.Client(ctx)
+		cfg := configFromAttributes(d.Get)
+		cfg.UserAgent = provider.UserAgent("terraform-provider-pihole", version)
+		cfg.SessionID = externalSessionID
+		// Sessions of every Terraform and provider version are reaped
+		cfg.ReapUserAgent = "terraform-provider-pihole/"
+		if cacheDir, err := os.UserCacheDir(); err == nil {
+			cfg.SessionJournal = filepath.Join(cacheDir, "terraform-provider-pihole", "sessions")
+		}
 
+		piholeClient, err := cfg.Client(ctx)
+		if errors.Is(err, pihole.ErrSeatsExceeded) {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("failed to instantiate client: %s", err),
+				Detail:   seatsExceededDetail(cfg.ReapStaleSessions),
+			}}
+		}
 		if err != nil {
-			return nil, diag.FromErr(fmt.Errorf("failed to instantiate client: %w", err))
+			return nil, diagFromErr(fmt.Errorf("failed to instantiate client: %w", err))
+		}
+
+		var diags diag.Diagnostics
+		if revoked := piholeClient.RevokedSessions(); len(revoked) > 0 {
+			diags = append(diags, revokedSessionsWarning(revoked, cfg.StaleSessionAge))
 		}
 
 		// Only register cleanup for sessions we created ourselves.
@@ -88,7 +237,35 @@ func configure(version string, provider *schema.Provider) func(ctx context.Conte
 		}
 
 		// Return ProviderMeta which wraps the client and provides coordination
-		return &ProviderMeta{Client: piholeClient}, nil
+		return &ProviderMeta{Client: piholeClient}, diags
+	}
+}
+
+// seatsExceededDetail explains a login rejected for lack of API seats, which
+// reap_stale_sessions did not recover from if reaping is set
+func seatsExceededDetail(reaping bool) string {
+	detail := "Every Pi-hole API session allowed by webserver.api.max_sessions is in use, often by runs that crashed before logging out. "
+	if reaping {
+		detail += "reap_stale_sessions revoked none of them: Pi-hole only lists sessions to a logged in client, so the provider borrows a session that an earlier run on this machine left behind to do so, and either none was left or none of the provider's sessions had been idle for stale_session_age. "
+	} else {
+		detail += "Set reap_stale_sessions to revoke the provider's stale sessions, with a session that an earlier run on this machine left behind if no seat is free. "
+	}
+	return detail + "Otherwise wait for the sessions to expire after webserver.session.timeout without activity, or raise webserver.api.max_sessions on the Pi-hole host."
+}
+
+// revokedSessionsWarning tells operators which sessions reap_stale_sessions
+// revoked, as they may belong to runs that were not crashed after all
+func revokedSessionsWarning(sessions []pihole.Session, age time.Duration) diag.Diagnostic {
+	lines := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		lines = append(lines, fmt.Sprintf("  - session %d from %s, last active %s", session.ID, session.RemoteAddr, formatTimestamp(session.LastActive)))
+	}
+
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Revoked %d stale Pi-hole API sessions", len(sessions)),
+		Detail: fmt.Sprintf("reap_stale_sessions revoked these sessions of earlier runs, which had been idle for at least %s:\n", age) +
+			strings.Join(lines, "\n"),
 	}
 }
 
@@ -2,12 +2,66 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+	"github.com/poindexter12/terraform-provider-pihole/internal/version"
 )
 
+// testAccRun runs tc against the Pi-hole at PIHOLE_URL when it is set, which
+// like any acceptance test also requires TF_ACC. Otherwise tc runs in the
+// normal unit test suite against an in-process fake Pi-hole, which seeds can
+// populate with state the provider cannot create itself.
+func testAccRun(t *testing.T, tc resource.TestCase, seeds ...func(*fake.Server)) {
+	t.Helper()
+
+	if os.Getenv("PIHOLE_URL") != "" {
+		resource.Test(t, tc)
+		return
+	}
+
+	testAccFake(t, seeds...)
+	resource.UnitTest(t, tc)
+}
+
+// testAccFake points the provider at an in-process fake Pi-hole populated by
+// seeds and returns it, for tests that check the seeded data or the state of
+// the fake afterwards. Those tests are skipped when PIHOLE_URL is set, as a
+// real Pi-hole holds other data.
+func testAccFake(t *testing.T, seeds ...func(*fake.Server)) *fake.Server {
+	t.Helper()
+
+	if os.Getenv("PIHOLE_URL") != "" {
+		t.Skip("checks data seeded into the fake Pi-hole")
+	}
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	for _, seed := range seeds {
+		seed(srv)
+	}
+
+	t.Setenv("PIHOLE_URL", srv.URL)
+	t.Setenv("PIHOLE_PASSWORD", fake.DefaultPassword)
+	t.Setenv("__PIHOLE_SESSION_ID", "")
+
+	return srv
+}
+
 func testAccPreCheck(t *testing.T) {
 	url := os.Getenv("PIHOLE_URL")
 	if url == "" {
@@ -41,14 +95,20 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
-var testAccProviders map[string]*schema.Provider
-var testAccProvider *schema.Provider
+// testAccProvider is the SDKv2 provider served by every test provider
+// server. Checks read the configured client from its meta.
+var testAccProvider = Provider()
 
-func init() {
-	testAccProvider = Provider()
-	testAccProviders = map[string]*schema.Provider{
-		"pihole": testAccProvider,
-	}
+// testAccProtoV5ProviderFactories serve testAccProvider together with the
+// framework resources, like the released provider
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"pihole": func() (tfprotov5.ProviderServer, error) {
+		server, err := newMuxServer(context.Background(), testAccProvider)
+		if err != nil {
+			return nil, err
+		}
+		return server(), nil
+	},
 }
 
 func TestProvider(t *testing.T) {
@@ -60,3 +120,186 @@ func TestProvider(t *testing.T) {
 func TestProviderImpl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
+
+// TestConfigFromEnvVars checks that EnvVars lists every environment variable
+// ConfigFromEnv reads
+func TestConfigFromEnvVars(t *testing.T) {
+	for _, name := range EnvVars {
+		t.Setenv(name, "")
+	}
+
+	var fromEnv int
+	for _, s := range Provider().Schema {
+		if s.DefaultFunc != nil {
+			fromEnv++
+		}
+	}
+	if fromEnv != len(EnvVars) {
+		t.Errorf("%d provider attributes default to an environment variable, EnvVars lists %d", fromEnv, len(EnvVars))
+	}
+
+	unset := ConfigFromEnv()
+	for _, name := range EnvVars {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, "set")
+			if reflect.DeepEqual(ConfigFromEnv(), unset) {
+				t.Errorf("ConfigFromEnv ignores %s", name)
+			}
+		})
+	}
+}
+
+// TestAccProviderReverseProxy tests reaching Pi-hole through an
+// authenticating reverse proxy under a path prefix
+func TestAccProviderReverseProxy(t *testing.T) {
+	if os.Getenv("PIHOLE_URL") != "" {
+		t.Skip("needs the fake Pi-hole behind a test reverse proxy")
+	}
+
+	// The fake only starts in testAccRun, so the proxy finds it late
+	var upstream *httputil.ReverseProxy
+	front := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		path, prefixed := strings.CutPrefix(r.URL.Path, "/pihole/")
+		if !ok || user != "terraform" || password != "secret" || r.Header.Get("X-Access-Token") != "token" || !prefixed {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		r.URL.Path = "/" + path
+		upstream.ServeHTTP(w, r)
+	}))
+	defer front.Close()
+
+	testAccRun(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "pihole" {
+					  url                 = "%s/pihole"
+					  basic_auth_username = "terraform"
+					  basic_auth_password = "secret"
+					  headers = {
+					    X-Access-Token = "token"
+					  }
+					}
+
+					resource "pihole_dns_record" "proxied" {
+					  domain = "proxied.lan"
+					  ip     = "10.0.48.1"
+					}
+
+					data "pihole_dns_records" "all" {
+					  depends_on = [pihole_dns_record.proxied]
+					}
+				`, front.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_dns_records.all", "ips_by_domain.proxied.lan", "10.0.48.1"),
+				),
+			},
+		},
+	}, func(srv *fake.Server) {
+		target, _ := url.Parse(srv.URL)
+		upstream = httputil.NewSingleHostReverseProxy(target)
+	})
+}
+
+// testConfigure configures a new provider against srv with the given
+// attributes on top of its URL and password
+func testConfigure(t *testing.T, srv *fake.Server, attributes map[string]interface{}) (*ProviderMeta, diag.Diagnostics) {
+	t.Helper()
+	t.Setenv("__PIHOLE_SESSION_ID", "")
+
+	attributes["url"] = srv.URL
+	attributes["password"] = fake.DefaultPassword
+
+	p := Provider()
+	meta, diags := p.ConfigureContextFunc(context.Background(), schema.TestResourceDataRaw(t, p.Schema, attributes))
+	pm, _ := meta.(*ProviderMeta)
+	if pm != nil {
+		t.Cleanup(func() { _ = pm.Client.Logout(context.Background()) })
+	}
+	return pm, diags
+}
+
+// testUserCache points the user cache directory, which holds the session
+// journal, to a directory of the test
+func testUserCache(t *testing.T) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("LocalAppData", dir)
+}
+
+func TestConfigureReapStaleSessions(t *testing.T) {
+	// Closed after the provider logs out
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+	testUserCache(t)
+
+	// The session of a crashed run holds the only other seat
+	srv.SetMaxSessions(2)
+	userAgent := Provider().UserAgent("terraform-provider-pihole", version.ProviderVersion)
+	srv.AgeSession(srv.AddSession(userAgent), time.Hour)
+
+	pm, diags := testConfigure(t, srv, map[string]interface{}{"reap_stale_sessions": true})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "Revoked 1 stale") || !strings.Contains(diags[0].Detail, "idle for at least 25m0s") {
+		t.Fatalf("expected a warning about the revoked session, got %v", diags)
+	}
+
+	sessions := srv.Sessions()
+	if len(sessions) != 1 || sessions[0].ID != pm.Client.SessionID() {
+		t.Errorf("expected only the provider's session to remain, got %v", sessions)
+	}
+
+	// The seat is free for the next run
+	if _, diags := testConfigure(t, srv, map[string]interface{}{}); len(diags) != 0 {
+		t.Errorf("expected the next run to log in, got %v", diags)
+	}
+}
+
+func TestConfigureSeatsExceeded(t *testing.T) {
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+	testUserCache(t)
+
+	srv.SetMaxSessions(1)
+	srv.AddSession("someone-else")
+
+	_, diags := testConfigure(t, srv, map[string]interface{}{})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "API seats exceeded") || !strings.Contains(diags[0].Detail, "webserver.session.timeout") {
+		t.Fatalf("expected an error explaining how seats free up, got %v", diags)
+	}
+}
+
+func TestConfigureRecoverSeats(t *testing.T) {
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+	testUserCache(t)
+
+	// A run on this machine holds one seat and a crashed run of an older
+	// version elsewhere holds the other
+	srv.SetMaxSessions(2)
+	if _, diags := testConfigure(t, srv, map[string]interface{}{"reap_stale_sessions": true}); len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	crashed := srv.AddSession("Terraform/1.5.7 (+https://www.terraform.io) Terraform-Plugin-SDK/2.30.0 terraform-provider-pihole/1.0.0")
+	srv.AgeSession(crashed, time.Hour)
+
+	pm, diags := testConfigure(t, srv, map[string]interface{}{"reap_stale_sessions": true})
+	if diags.HasError() {
+		t.Fatalf("expected the login to recover, got %v", diags)
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Summary, "Revoked 1 stale") {
+		t.Errorf("expected a warning about the revoked session, got %v", diags)
+	}
+	if pm.Client.SessionID() == "" {
+		t.Error("expected the provider to log in")
+	}
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+)
+
+// actionNote is appended to the description of every action resource
+const actionNote = " The action runs when the resource is created, and again whenever `triggers` changes. " +
+	"Destroying the resource only removes it from the Terraform state. " +
+	"Pi-hole only accepts actions when `webserver.api.allow_destructive` is enabled."
+
+// resourceRestartDNS returns the Terraform resource that restarts Pi-hole's DNS resolver
+func resourceRestartDNS() *schema.Resource {
+	return actionResource(
+		"Restarts FTL's DNS resolver, for example after `dns.hosts` was edited outside of Terraform. "+
+			"Creating the resource waits until the restarted FTL responds to API requests again."+actionNote,
+		pihole.ActionService.RestartDNS,
+	)
+}
+
+// resourceFlushLogs returns the Terraform resource that empties Pi-hole's query log
+func resourceFlushLogs() *schema.Resource {
+	return actionResource("Empties Pi-hole's query log."+actionNote, pihole.ActionService.FlushLogs)
+}
+
+// resourceFlushARP returns the Terraform resource that empties Pi-hole's ARP cache
+func resourceFlushARP() *schema.Resource {
+	return actionResource(
+		"Empties Pi-hole's ARP cache, removing the addresses of all devices in the network table."+actionNote,
+		pihole.ActionService.FlushARP,
+	)
+}
+
+// resourceFlushNetwork returns the Terraform resource that empties Pi-hole's network table
+func resourceFlushNetwork() *schema.Resource {
+	return actionResource("Empties Pi-hole's network table, removing all devices."+actionNote, pihole.ActionService.FlushNetwork)
+}
+
+// actionResource returns a trigger-style resource that runs action on create
+func actionResource(description string, action func(pihole.ActionService, context.Context) error) *schema.Resource {
+	return &schema.Resource{
+		Description: description,
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+			pm, diags := getProviderMeta(meta)
+			if diags != nil {
+				return diags
+			}
+
+			pm.Lock()
+			defer pm.Unlock()
+
+			if err := action(pm.Client.Actions(), ctx); err != nil {
+				return diagFromErr(err)
+			}
+
+			d.SetId(strconv.FormatInt(time.Now().UnixNano(), 10))
+
+			return diags
+		},
+		// Read is a no-op, an action has no remote state to refresh
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+		// Delete only removes the resource from the state
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("")
+			return nil
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"triggers": {
+				Description: "Arbitrary values that run the action again when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+// testAccRestartDNSConfig restarts DNS whenever trigger changes
+func testAccRestartDNSConfig(trigger string) string {
+	return fmt.Sprintf(`
+		resource "pihole_restart_dns" "test" {
+		  triggers = {
+		    hosts = %q
+		  }
+		}
+	`, trigger)
+}
+
+func TestAccRestartDNS(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestartDNSConfig("1"),
+				Check:  resource.TestCheckResourceAttrSet("pihole_restart_dns.test", "id"),
+			},
+			{
+				Config: testAccRestartDNSConfig("2"),
+				Check:  resource.TestCheckResourceAttrSet("pihole_restart_dns.test", "id"),
+			},
+		},
+	})
+}
+
+func TestAccRestartDNSTriggers(t *testing.T) {
+	srv := testAccFake(t, func(srv *fake.Server) {
+		srv.SetRestartDowntime(200 * time.Millisecond)
+	})
+
+	testAccCheckRestarts := func(n int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			if got := srv.Restarts(); got != n {
+				return fmt.Errorf("expected %d DNS restarts, got %d", n, got)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRestartDNSConfig("1"),
+				Check:  testAccCheckRestarts(1),
+			},
+			{
+				Config: testAccRestartDNSConfig("2"),
+				Check:  testAccCheckRestarts(2),
+			},
+		},
+	})
+}
+
+func TestAccFlushActions(t *testing.T) {
+	// The fake also keeps the flushes away from a real query log and network table
+	srv := testAccFake(t, func(srv *fake.Server) {
+		srv.AddQuery(fake.Query{Time: 1700000000, Type: "A", Domain: "example.com", Status: "FORWARDED", ClientIP: "192.168.1.10"})
+		srv.AddDevice(fake.Device{HWAddr: "aa:bb:cc:dd:ee:01", Addresses: []fake.Address{{IP: "192.168.1.10"}}})
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_flush_logs" "test" {}
+
+					resource "pihole_flush_arp" "test" {}
+
+					resource "pihole_flush_network" "test" {
+					  depends_on = [pihole_flush_arp.test]
+					}
+				`,
+				Check: func(s *terraform.State) error {
+					if devices := srv.Devices(); len(devices) != 0 {
+						return fmt.Errorf("expected an empty network table, got %v", devices)
+					}
+					return nil
+				},
+			},
+			{
+				// Reads the query log after the flush of the first step
+				Config: `
+					resource "pihole_flush_logs" "test" {}
+
+					data "pihole_queries" "all" {}
+				`,
+				Check: resource.TestCheckResourceAttr("data.pihole_queries.all", "queries.#", "0"),
+			},
+		},
+	})
+}
@@ -3,129 +3,445 @@ package provider
 import (
 	"context"
 	"errors"
+	"slices"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
-// resourceClient returns the Pi-hole client Terraform resource management configuration
-func resourceClient() *schema.Resource {
-	return &schema.Resource{
-		Description:   "Manages a Pi-hole client configuration. Clients can be IP addresses, MAC addresses, hostnames, CIDR ranges, or interface names.",
-		CreateContext: resourceClientCreate,
-		ReadContext:   resourceClientRead,
-		UpdateContext: resourceClientUpdate,
-		DeleteContext: resourceClientDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
-			"client": {
-				Description: "Client identifier (IP address, MAC address, hostname, CIDR range, or interface name)",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+// clientResource manages a Pi-hole client configuration
+type clientResource struct {
+	pm *ProviderMeta
+}
+
+// clientModel is the state of a pihole_client, in the format written by the
+// SDKv2 implementation of the resource plus the client type and the
+// attributes Pi-hole computes
+type clientModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Client       types.String   `tfsdk:"client"`
+	Comment      types.String   `tfsdk:"comment"`
+	Type         types.String   `tfsdk:"type"`
+	DatabaseID   types.Int64    `tfsdk:"database_id"`
+	Name         types.String   `tfsdk:"name"`
+	Vendor       types.String   `tfsdk:"vendor"`
+	Groups       types.List     `tfsdk:"groups"`
+	DateAdded    types.String   `tfsdk:"date_added"`
+	DateModified types.String   `tfsdk:"date_modified"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// set sets the attributes Pi-hole computes from record and the network
+// table devices
+func (m *clientModel) set(ctx context.Context, record *pihole.ClientRecord, devices []pihole.NetworkDevice) diag.Diagnostics {
+	attrs, diags := newClientAttributes(ctx, record, devices)
+	m.DatabaseID = attrs.DatabaseID
+	m.Name = attrs.Name
+	m.Vendor = attrs.Vendor
+	m.Groups = attrs.Groups
+	m.DateAdded = attrs.DateAdded
+	m.DateModified = attrs.DateModified
+	return diags
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &clientResource{}
+	_ resource.ResourceWithImportState = &clientResource{}
+	_ resource.ResourceWithModifyPlan  = &clientResource{}
+)
+
+// newClientResource returns the Pi-hole client Terraform resource
+func newClientResource() resource.Resource {
+	return &clientResource{}
+}
+
+// Metadata returns the resource type name
+func (r *clientResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client"
+}
+
+// Schema returns the resource schema
+func (r *clientResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Pi-hole client configuration. Clients can be IP addresses, MAC addresses, hostnames, CIDR ranges, or interface names.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The ID of this resource.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"comment": {
+			"client": schema.StringAttribute{
+				Description: "Client identifier (IP address, MAC address, hostname, CIDR range, or interface name prefixed with a colon such as `:eth0`). " +
+					"Equivalent spellings, such as MAC addresses in upper or lower case, refer to the same client, and changing between them shows no difference. " +
+					"MAC addresses in the dotted form `aabb.ccdd.eeff` are hostnames.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{clientSpellingModifier{}, stringplanmodifier.RequiresReplaceIf(
+					clientChanged,
+					"Changing the client to a different identifier replaces the client.",
+					"Changing the client to a different identifier replaces the client.",
+				)},
+				Validators: []validator.String{clientIDValidator()},
+			},
+			"comment": schema.StringAttribute{
 				Description: "Optional comment for the client",
-				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "",
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"type": schema.StringAttribute{
+				Description:   "Kind of client identifier: `ipv4`, `ipv6`, `cidr`, `mac`, `hostname` or `interface`",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{clientTypeModifier{}},
+			},
+			"database_id": schema.Int64Attribute{
+				Description:   "ID of the client in Pi-hole's gravity database, which groups refer to clients by. `id` is the client identifier.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Description: "Hostname Pi-hole resolved for the client, empty if it has none",
+				Computed:    true,
+			},
+			"vendor": schema.StringAttribute{
+				Description: "Vendor of the network device the client refers to, derived from its hardware address. Empty for clients that are not a single known device.",
+				Computed:    true,
+			},
+			"groups": schema.ListAttribute{
+				Description: "IDs of the groups the client is assigned to, in ascending order. The default group is `0`.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+			"date_added": schema.StringAttribute{
+				Description:   "When the client was added, in RFC 3339 format",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"date_modified": schema.StringAttribute{
+				Description: "When the client was last modified, in RFC 3339 format",
+				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Update: true, Delete: true}),
+		},
 	}
 }
 
-// resourceClientCreate handles the creation of a client record via Terraform
-func resourceClientCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
+// Configure stores the provider meta
+func (r *clientResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	pm, diags := providerMetaFromData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.pm = pm
+}
+
+// Create handles the creation of a client record via Terraform
+func (r *clientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan clientModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	client := d.Get("client").(string)
-	comment := d.Get("comment").(string)
+	timeout, diags := plan.Timeouts.Create(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	pm.Lock()
-	defer pm.Unlock()
+	r.pm.Lock()
+	defer r.pm.Unlock()
 
-	_, err := pm.Client.ClientManagement().Create(ctx, client, comment)
+	client := plan.Client.ValueString()
+	record, err := r.pm.Client.ClientManagement().Create(ctx, client, plan.Comment.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		addErr(&resp.Diagnostics, err)
+		return
 	}
 
-	d.SetId(client)
+	// The client exists now, so it is saved even if its vendor is unknown.
+	devices, _ := r.devices(ctx, client, &resp.Diagnostics)
 
-	return diags
+	plan.ID = types.StringValue(client)
+	plan.Type = clientType(client)
+	resp.Diagnostics.Append(plan.set(ctx, record, devices)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// resourceClientRead finds a client record based on the client ID
-func resourceClientRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
+// Read finds a client record based on the client ID
+func (r *clientResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state clientModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	pm.Lock()
-	defer pm.Unlock()
+	timeout, diags := state.Timeouts.Read(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	record, err := pm.Client.ClientManagement().Get(ctx, d.Id())
+	r.pm.RLock()
+	defer r.pm.RUnlock()
+
+	record, err := r.pm.Client.ClientManagement().Get(ctx, state.ID.ValueString())
 	if err != nil {
 		if errors.Is(err, pihole.ErrClientNotFound) {
-			d.SetId("")
-			return nil
+			resp.State.RemoveResource(ctx)
+			return
 		}
 
-		return diag.FromErr(err)
+		addErr(&resp.Diagnostics, err)
+		return
 	}
 
-	if err = d.Set("client", record.Client); err != nil {
-		return diag.FromErr(err)
+	// Pi-hole canonicalises identifiers, so the configured spelling is kept
+	// while it refers to the same client
+	if !sameClient(state.Client.ValueString(), record.Client) {
+		state.Client = types.StringValue(record.Client)
 	}
+	state.Comment = types.StringValue(record.Comment)
+	state.Type = clientType(state.Client.ValueString())
 
-	if err = d.Set("comment", record.Comment); err != nil {
-		return diag.FromErr(err)
+	vendor := state.Vendor
+	devices, ok := r.devices(ctx, record.Client, &resp.Diagnostics)
+	resp.Diagnostics.Append(state.set(ctx, record, devices)...)
+	if !ok && !vendor.IsNull() {
+		state.Vendor = vendor
 	}
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-// resourceClientUpdate handles updating a client record via Terraform
-func resourceClientUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
+// Update handles updating a client record via Terraform
+func (r *clientResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan clientModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	comment := d.Get("comment").(string)
+	timeout, diags := plan.Timeouts.Update(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	pm.Lock()
-	defer pm.Unlock()
+	r.pm.Lock()
+	defer r.pm.Unlock()
 
-	_, err := pm.Client.ClientManagement().Update(ctx, d.Id(), comment)
+	record, err := r.pm.Client.ClientManagement().Update(ctx, plan.ID.ValueString(), plan.Comment.ValueString())
 	if err != nil {
-		return diag.FromErr(err)
+		addErr(&resp.Diagnostics, err)
+		return
 	}
 
-	return diags
+	devices, ok := r.devices(ctx, record.Client, &resp.Diagnostics)
+
+	plan.Type = clientType(plan.Client.ValueString())
+	resp.Diagnostics.Append(plan.set(ctx, record, devices)...)
+	if !ok {
+		// The client is the same device, so its vendor is the one last seen
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("vendor"), &plan.Vendor)...)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// resourceClientDelete handles the deletion of a client record via Terraform
-func resourceClientDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
+// Delete handles the deletion of a client record via Terraform
+func (r *clientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state clientModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	pm.Lock()
-	defer pm.Unlock()
+	r.pm.Lock()
+	defer r.pm.Unlock()
 
-	if err := pm.Client.ClientManagement().Delete(ctx, d.Id()); err != nil {
-		return diag.FromErr(err)
+	if err := r.pm.Client.ClientManagement().Delete(ctx, state.ID.ValueString()); err != nil {
+		addErr(&resp.Diagnostics, err)
 	}
+}
 
-	d.SetId("")
+// devices returns the network table the vendor of client is looked up in.
+// If the table cannot be read a warning is added and ok is false, leaving
+// the vendor empty.
+func (r *clientResource) devices(ctx context.Context, client string, diags *diag.Diagnostics) (devices []pihole.NetworkDevice, ok bool) {
+	devices, err := r.pm.Client.Network().List(ctx)
+	if err != nil {
+		diags.AddWarning(vendorWarning(client, err))
+		return nil, false
+	}
+	return devices, true
+}
 
-	return diags
+// vendorWarning returns the summary and detail of the warning given when the
+// network table that the vendors of clients are looked up in cannot be read.
+// The vendor is only informational, so clients are read without it.
+func vendorWarning(clients string, err error) (summary, detail string) {
+	return "Failed to look up the vendor of " + clients, "The network table could not be read: " + err.Error()
+}
+
+// ModifyPlan keeps the state when the client is only respelled. The framework
+// marks the computed attributes unknown once the configuration differs from
+// the state, which would show an update even though the client stays the
+// same.
+func (r *clientResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state clientModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Client.Equal(state.Client) && plan.Comment.Equal(state.Comment) && plan.Timeouts.Equal(state.Timeouts) {
+		resp.Plan.Raw = req.State.Raw
+	}
+}
+
+// ImportState imports a client by its identifier
+func (r *clientResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// clientAttributes are the attributes of a client that Pi-hole computes,
+// shared by the pihole_client resource and data source
+type clientAttributes struct {
+	DatabaseID   types.Int64
+	Name         types.String
+	Vendor       types.String
+	Groups       types.List
+	DateAdded    types.String
+	DateModified types.String
+}
+
+// newClientAttributes returns the computed attributes of record. The vendor
+// is looked up in the network table devices.
+func newClientAttributes(ctx context.Context, record *pihole.ClientRecord, devices []pihole.NetworkDevice) (clientAttributes, diag.Diagnostics) {
+	groups := slices.Clone(record.Groups)
+	slices.Sort(groups)
+	if groups == nil {
+		groups = []int{}
+	}
+	groupsValue, diags := types.ListValueFrom(ctx, types.Int64Type, groups)
+
+	device, _ := pihole.ClientDevice(devices, record.Client)
+
+	return clientAttributes{
+		DatabaseID:   types.Int64Value(int64(record.ID)),
+		Name:         types.StringValue(record.Name),
+		Vendor:       types.StringValue(device.Vendor),
+		Groups:       groupsValue,
+		DateAdded:    types.StringValue(formatTimestamp(record.DateAdded)),
+		DateModified: types.StringValue(formatTimestamp(record.DateModified)),
+	}, diags
+}
+
+// sameClient reports whether two client identifiers refer to the same client
+func sameClient(a, b string) bool {
+	_, canonicalA, errA := pihole.ParseClientID(a)
+	_, canonicalB, errB := pihole.ParseClientID(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return canonicalA == canonicalB
+}
+
+// clientType returns the kind of a client identifier, or null if it is not
+// a valid identifier
+func clientType(client string) types.String {
+	kind, _, err := pihole.ParseClientID(client)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(string(kind))
+}
+
+// clientChanged requires replacement when the client identifier changes to
+// one referring to a different client
+func clientChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !sameClient(req.StateValue.ValueString(), req.PlanValue.ValueString())
+}
+
+// clientSpellingModifier plans the client identifier in state while the
+// configured one is a different spelling of the same client, so respelling
+// it shows no difference
+type clientSpellingModifier struct{}
+
+var _ planmodifier.String = clientSpellingModifier{}
+
+// Description returns a plain text description of the modifier
+func (m clientSpellingModifier) Description(ctx context.Context) string {
+	return "Equivalent spellings of the client identifier keep the one in state."
+}
+
+// MarkdownDescription returns a markdown description of the modifier
+func (m clientSpellingModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString keeps the identifier in state if it refers to the same
+// client as the configured one
+func (m clientSpellingModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() || req.PlanValue.IsNull() {
+		return
+	}
+
+	if sameClient(req.StateValue.ValueString(), req.PlanValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// clientTypeModifier plans the type of the configured client identifier
+type clientTypeModifier struct{}
+
+var _ planmodifier.String = clientTypeModifier{}
+
+// Description returns a plain text description of the modifier
+func (m clientTypeModifier) Description(ctx context.Context) string {
+	return "The type is derived from the client identifier."
+}
+
+// MarkdownDescription returns a markdown description of the modifier
+func (m clientTypeModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString sets the type when the client identifier is known
+func (m clientTypeModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var client types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("client"), &client)...)
+	if resp.Diagnostics.HasError() || client.IsUnknown() || client.IsNull() {
+		return
+	}
+
+	resp.PlanValue = clientType(client.ValueString())
 }
@@ -4,25 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
 )
 
 // TestAccClient acceptance test for the client resource
 func TestAccClient(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckClientDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckClientDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testClientResourceConfig("testclient", "192.168.100.1", "Test client"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("pihole_client.testclient", "client", "192.168.100.1"),
 					resource.TestCheckResourceAttr("pihole_client.testclient", "comment", "Test client"),
+					resource.TestCheckResourceAttr("pihole_client.testclient", "type", "ipv4"),
 					testCheckClientResourceExists(t, "192.168.100.1", "Test client"),
 				),
 			},
@@ -41,10 +46,10 @@ func TestAccClient(t *testing.T) {
 
 // TestAccClientEmptyComment tests creating a client with no comment
 func TestAccClientEmptyComment(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckClientDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckClientDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testClientResourceConfigNoComment("emptyclient", "192.168.100.2"),
@@ -60,20 +65,186 @@ func TestAccClientEmptyComment(t *testing.T) {
 
 // TestAccClientMAC tests creating a client using MAC address
 func TestAccClientMAC(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckClientDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckClientDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testClientResourceConfig("macclient", "AA:BB:CC:DD:EE:FF", "MAC address client"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("pihole_client.macclient", "client", "AA:BB:CC:DD:EE:FF"),
 					resource.TestCheckResourceAttr("pihole_client.macclient", "comment", "MAC address client"),
+					resource.TestCheckResourceAttr("pihole_client.macclient", "type", "mac"),
+				),
+			},
+			// Pi-hole stores MAC addresses in lower case, which must not show a diff
+			{
+				Config:   testClientResourceConfig("macclient", "AA:BB:CC:DD:EE:FF", "MAC address client"),
+				PlanOnly: true,
+			},
+			// Respelling the MAC address refers to the same client
+			{
+				Config:   testClientResourceConfig("macclient", "aa-bb-cc-dd-ee-ff", "MAC address client"),
+				PlanOnly: true,
+			},
+			// and keeps the spelling in state when the client is updated
+			{
+				Config: testClientResourceConfig("macclient", "aa-bb-cc-dd-ee-ff", "Respelled MAC address client"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_client.macclient", "client", "AA:BB:CC:DD:EE:FF"),
+					resource.TestCheckResourceAttr("pihole_client.macclient", "comment", "Respelled MAC address client"),
+					testCheckClientResourceExists(t, "aa:bb:cc:dd:ee:ff", "Respelled MAC address client"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccClientTypes tests the classification of client identifiers
+func TestAccClientTypes(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckClientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testClientResourceConfig("ipv6", "fd00::10", "") +
+					testClientResourceConfig("cidr", "192.168.110.0/24", "") +
+					testClientResourceConfig("hostname", "laptop.lan", "") +
+					testClientResourceConfig("interface", ":eth9", "") +
+					testClientResourceConfig("dotted", "aabb.ccdd.eeff", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_client.ipv6", "type", "ipv6"),
+					resource.TestCheckResourceAttr("pihole_client.cidr", "type", "cidr"),
+					resource.TestCheckResourceAttr("pihole_client.hostname", "type", "hostname"),
+					resource.TestCheckResourceAttr("pihole_client.interface", "type", "interface"),
+					// The dotted MAC address form is ambiguous and taken as a hostname
+					resource.TestCheckResourceAttr("pihole_client.dotted", "type", "hostname"),
+				),
+			},
+		},
+	})
+}
+
+// rfc3339Regex matches timestamps in RFC 3339 format in UTC
+var rfc3339Regex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)
+
+// TestAccClientAttributes tests the attributes Pi-hole computes for a client
+func TestAccClientAttributes(t *testing.T) {
+	var databaseID string
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttrWith("pihole_client.device", "database_id", func(v string) error {
+			databaseID = v
+			return nil
+		}),
+		resource.TestCheckResourceAttr("pihole_client.device", "groups.#", "1"),
+		resource.TestCheckResourceAttr("pihole_client.device", "groups.0", "0"),
+		resource.TestMatchResourceAttr("pihole_client.device", "date_added", rfc3339Regex),
+		resource.TestMatchResourceAttr("pihole_client.device", "date_modified", rfc3339Regex),
+		resource.TestCheckResourceAttr("pihole_client.range", "vendor", ""),
+	}
+	if os.Getenv("PIHOLE_URL") == "" {
+		// The fake resolves names from the seeded network table
+		checks = append(checks,
+			resource.TestCheckResourceAttr("pihole_client.device", "name", "printer.lan"),
+			resource.TestCheckResourceAttr("pihole_client.device", "vendor", "Brother Industries"),
+		)
+	}
+
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckClientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testClientResourceConfig("device", "02:00:00:00:47:01", "Printer") +
+					testClientResourceConfig("range", "192.168.47.0/24", ""),
+				Check: resource.ComposeTestCheckFunc(checks...),
+			},
+			// The database ID stays when the comment changes
+			{
+				Config: testClientResourceConfig("device", "02:00:00:00:47:01", "Office printer") +
+					testClientResourceConfig("range", "192.168.47.0/24", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_client.device", "comment", "Office printer"),
+					resource.TestCheckResourceAttrWith("pihole_client.device", "database_id", func(v string) error {
+						if v == "" || v != databaseID {
+							return fmt.Errorf("expected database ID %q, got %q", databaseID, v)
+						}
+						return nil
+					}),
 				),
 			},
 		},
+	}, func(s *fake.Server) {
+		s.AddDevice(fake.Device{
+			HWAddr:    "02:00:00:00:47:01",
+			Vendor:    "Brother Industries",
+			Addresses: []fake.Address{{IP: "192.168.47.10", Name: "printer.lan"}},
+		})
+	})
+}
+
+// TestAccClientVendorUnavailable tests that a client is saved, and keeps its
+// vendor, when the network table cannot be read
+func TestAccClientVendorUnavailable(t *testing.T) {
+	srv := testAccFake(t, func(s *fake.Server) {
+		s.AddDevice(fake.Device{HWAddr: "02:00:00:00:47:02", Vendor: "Brother Industries"})
 	})
+
+	config := func(comment string) string {
+		return `
+			provider "pihole" {
+				max_retries = 0
+			}
+		` + testClientResourceConfig("device", "02:00:00:00:47:02", comment)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckClientDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { srv.FailNetworkTable(1) },
+				Config:    config("Printer"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckClientResourceExists(t, "02:00:00:00:47:02", "Printer"),
+					resource.TestCheckResourceAttr("pihole_client.device", "vendor", ""),
+				),
+			},
+			// The vendor, filled in by the refresh after the first step, is
+			// kept by updates and refreshes while the table cannot be read
+			{
+				PreConfig: func() { srv.FailNetworkTable(math.MaxInt) },
+				Config:    config("Office printer"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckClientResourceExists(t, "02:00:00:00:47:02", "Office printer"),
+					resource.TestCheckResourceAttr("pihole_client.device", "vendor", "Brother Industries"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccClientInvalid tests that malformed identifiers are rejected at plan time
+func TestAccClientInvalid(t *testing.T) {
+	for _, client := range []string{"192.168.1.0/33", "laptop lan", ":", "-laptop"} {
+		// Each case needs its own test, as testAccRun points the environment
+		// at its fake Pi-hole for the rest of the test
+		t.Run(client, func(t *testing.T) {
+			testAccRun(t, resource.TestCase{
+				ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      testClientResourceConfig("invalid", client, ""),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+					},
+				},
+			})
+		})
+	}
 }
 
 // testClientResourceConfig returns HCL to configure a client resource
@@ -117,10 +288,10 @@ func testCheckClientResourceExists(_ *testing.T, client, comment string) resourc
 // This verifies the mutex prevents race conditions in the Pi-hole API.
 func TestAccClientStress(t *testing.T) {
 	const count = 20
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckClientDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckClientDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testClientStressConfig(count),
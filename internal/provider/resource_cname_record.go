@@ -4,121 +4,211 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
-// resourceCNAMERecord returns the CNAME Terraform resource management configuration
-func resourceCNAMERecord() *schema.Resource {
-	return &schema.Resource{
-		Description:   "Manages a Pi-hole CNAME record",
-		CreateContext: resourceCNAMERecordCreate,
-		ReadContext:   resourceCNAMERecordRead,
-		DeleteContext: resourceCNAMERecordDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
-			"domain": {
-				Description:      "Domain to create a CNAME record for",
-				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validateDomain(),
+// cnameRecordResource manages a CNAME record
+type cnameRecordResource struct {
+	pm *ProviderMeta
+}
+
+// cnameRecordModel is the state of a pihole_cname_record, in the format written
+// by the SDKv2 implementation of the resource
+type cnameRecordModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Domain   types.String   `tfsdk:"domain"`
+	Target   types.String   `tfsdk:"target"`
+	Force    types.Bool     `tfsdk:"force"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &cnameRecordResource{}
+	_ resource.ResourceWithImportState = &cnameRecordResource{}
+)
+
+// newCNAMERecordResource returns the CNAME Terraform resource
+func newCNAMERecordResource() resource.Resource {
+	return &cnameRecordResource{}
+}
+
+// Metadata returns the resource type name
+func (r *cnameRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cname_record"
+}
+
+// Schema returns the resource schema
+func (r *cnameRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Pi-hole CNAME record. " +
+			"Pi-hole replaces its CNAME records as a whole list, so when another tool edits them at the same time the last writer wins. The provider writes its own records again if they were lost, but records the other tool added may be lost.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The ID of this resource.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"domain": schema.StringAttribute{
+				Description:   "Domain to create a CNAME record for",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    []validator.String{domainValidator()},
 			},
-			"target": {
-				Description:      "Value of the CNAME record where traffic will be directed to from the configured domain value",
-				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validateDomain(),
+			"target": schema.StringAttribute{
+				Description:   "Value of the CNAME record where traffic will be directed to from the configured domain value",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    []validator.String{domainValidator()},
 			},
-			"force": {
-				Description: "Attempt to force record creation. Note: Pi-hole v6 API currently does not implement this for CNAME endpoints, but it is included for forward compatibility with future Pi-hole versions.",
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				ForceNew:    true,
+			"force": schema.BoolAttribute{
+				Description:   "Attempt to force record creation. Note: Pi-hole v6 API currently does not implement this for CNAME endpoints, but it is included for forward compatibility with future Pi-hole versions.",
+				Optional:      true,
+				Computed:      true,
+				Default:       booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
 	}
 }
 
-// resourceCNAMERecordCreate handles the creation a CNAME record via Terraform
-func resourceCNAMERecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
-	}
-
-	domain := d.Get("domain").(string)
-	target := d.Get("target").(string)
-	force := d.Get("force").(bool)
-
-	// Acquire global mutex to serialize all Pi-hole API operations
-	pm.Lock()
-	defer pm.Unlock()
+// Configure stores the provider meta
+func (r *cnameRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	pm, diags := providerMetaFromData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.pm = pm
+}
 
-	opts := &pihole.CreateOptions{Force: force}
-	_, err := pm.Client.LocalCNAME().Create(ctx, domain, target, opts)
-	if err != nil {
-		return diag.FromErr(err)
+// Create handles the creation a CNAME record via Terraform
+func (r *cnameRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan cnameRecordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId(domain)
+	timeout, diags := plan.Timeouts.Create(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	domain := plan.Domain.ValueString()
+
+	// Record writes are batched by the client, so only writes to the same
+	// record need to be ordered
+	r.pm.RLock()
+	defer r.pm.RUnlock()
+	r.pm.LockRecord("cname/" + domain)
+	defer r.pm.UnlockRecord("cname/" + domain)
+
+	opts := &pihole.CreateOptions{Force: plan.Force.ValueBool()}
+	if _, err := r.pm.Client.LocalCNAME().Create(ctx, domain, plan.Target.ValueString(), opts); err != nil {
+		addErr(&resp.Diagnostics, err)
+		return
+	}
 
-	return diags
+	plan.ID = types.StringValue(domain)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// resourceCNAMERecordRead retrieves the CNAME record of the associated domain ID
-func resourceCNAMERecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
+// Read retrieves the CNAME record of the associated domain ID
+func (r *cnameRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state cnameRecordModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// Read operations also acquire the mutex to prevent reads during writes
-	pm.Lock()
-	defer pm.Unlock()
+	r.pm.RLock()
+	defer r.pm.RUnlock()
 
-	record, err := pm.Client.LocalCNAME().Get(ctx, d.Id())
+	record, err := r.pm.Client.LocalCNAME().Get(ctx, state.ID.ValueString())
 	if err != nil {
 		if errors.Is(err, pihole.ErrCNAMENotFound) {
-			d.SetId("")
-			return nil
+			resp.State.RemoveResource(ctx)
+			return
 		}
 
-		return diag.FromErr(err)
+		addErr(&resp.Diagnostics, err)
+		return
 	}
 
-	if err = d.Set("domain", record.Domain); err != nil {
-		return diag.FromErr(err)
-	}
+	state.Domain = types.StringValue(record.Domain)
+	state.Target = types.StringValue(record.Target)
 
-	if err = d.Set("target", record.Target); err != nil {
-		return diag.FromErr(err)
+	// Imported records have no force value yet
+	if state.Force.IsNull() {
+		state.Force = types.BoolValue(false)
 	}
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-// resourceCNAMERecordDelete handles the deletion of a CNAME record via Terraform
-func resourceCNAMERecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
+// Update only stores changed timeouts, every other change replaces the record
+func (r *cnameRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan cnameRecordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Acquire global mutex to serialize all Pi-hole API operations
-	pm.Lock()
-	defer pm.Unlock()
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles the deletion of a CNAME record via Terraform
+func (r *cnameRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state cnameRecordModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if err := pm.Client.LocalCNAME().Delete(ctx, d.Id()); err != nil {
-		return diag.FromErr(err)
+	timeout, diags := state.Timeouts.Delete(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	domain := state.ID.ValueString()
 
-	d.SetId("")
+	// Record writes are batched by the client, so only writes to the same
+	// record need to be ordered
+	r.pm.RLock()
+	defer r.pm.RUnlock()
+	r.pm.LockRecord("cname/" + domain)
+	defer r.pm.UnlockRecord("cname/" + domain)
+
+	if err := r.pm.Client.LocalCNAME().Delete(ctx, domain); err != nil {
+		addErr(&resp.Diagnostics, err)
+	}
+}
 
-	return diags
+// ImportState imports a record by its domain
+func (r *cnameRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
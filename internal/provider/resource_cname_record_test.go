@@ -13,10 +13,10 @@ import (
 
 // TestAccCNAMERecord acceptance test for the CNAME record resource
 func TestAccCNAMERecord(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckCNAMERecordDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckCNAMERecordDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testLocalCNAMEResourceConfig("foo", "foo.com", "bar.com"),
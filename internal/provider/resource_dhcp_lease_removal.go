@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceDHCPLeaseRemoval returns the Terraform resource that revokes a Pi-hole DHCP lease
+func resourceDHCPLeaseRemoval() *schema.Resource {
+	return &schema.Resource{
+		Description: "Revokes the DHCP lease of an IP address, so the client has to request a new one. " +
+			"The lease is revoked when the resource is created, and again whenever one of its arguments changes. " +
+			"Destroying the resource only removes it from the Terraform state.",
+		CreateContext: resourceDHCPLeaseRemovalCreate,
+		ReadContext:   resourceDHCPLeaseRemovalRead,
+		DeleteContext: resourceDHCPLeaseRemovalDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"ip": {
+				Description:      "IP address whose lease is revoked",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateIPAddress(),
+			},
+			"triggers": {
+				Description: "Arbitrary values that revoke the lease again when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourceDHCPLeaseRemovalCreate revokes the lease
+func resourceDHCPLeaseRemovalCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	ip := d.Get("ip").(string)
+
+	pm.Lock()
+	defer pm.Unlock()
+
+	if err := pm.Client.DHCP().Delete(ctx, ip); err != nil {
+		return diagFromErr(err)
+	}
+
+	d.SetId(ip)
+
+	return diags
+}
+
+// resourceDHCPLeaseRemovalRead is a no-op, a revoked lease has no remote state to refresh
+func resourceDHCPLeaseRemovalRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceDHCPLeaseRemovalDelete removes the revocation from the state without touching Pi-hole
+func resourceDHCPLeaseRemovalDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
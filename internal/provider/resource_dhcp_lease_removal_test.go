@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+// testAccDHCPLeaseRemovalConfig uses a documentation address so running
+// against a real Pi-hole revokes nothing
+const testAccDHCPLeaseRemovalConfig = `
+	resource "pihole_dhcp_lease_removal" "test" {
+	  ip = "192.0.2.10"
+	}
+`
+
+func TestAccDHCPLeaseRemoval(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDHCPLeaseRemovalConfig,
+				Check:  resource.TestCheckResourceAttr("pihole_dhcp_lease_removal.test", "id", "192.0.2.10"),
+			},
+		},
+	})
+}
+
+func TestAccDHCPLeaseRemovalRevokes(t *testing.T) {
+	srv := testAccFake(t, func(srv *fake.Server) {
+		srv.AddLease(fake.Lease{IP: "192.0.2.10", HWAddr: "aa:bb:cc:dd:ee:01"})
+		srv.AddLease(fake.Lease{IP: "192.0.2.11", HWAddr: "aa:bb:cc:dd:ee:02"})
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDHCPLeaseRemovalConfig,
+				Check: func(s *terraform.State) error {
+					if leases := srv.Leases(); len(leases) != 1 || leases[0].IP != "192.0.2.11" {
+						return fmt.Errorf("expected only the other lease to remain, got %v", leases)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -4,121 +4,211 @@ import (
 	"context"
 	"errors"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
-// resourceDNSRecord returns the local DNS Terraform resource management configuration
-func resourceDNSRecord() *schema.Resource {
-	return &schema.Resource{
-		Description:   "Manages a Pi-hole DNS record",
-		CreateContext: resourceDNSRecordCreate,
-		ReadContext:   resourceDNSRecordRead,
-		DeleteContext: resourceDNSRecordDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
-			"domain": {
-				Description:      "DNS record domain",
-				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validateDomain(),
+// dnsRecordResource manages a local DNS record
+type dnsRecordResource struct {
+	pm *ProviderMeta
+}
+
+// dnsRecordModel is the state of a pihole_dns_record, in the format written
+// by the SDKv2 implementation of the resource
+type dnsRecordModel struct {
+	ID       types.String   `tfsdk:"id"`
+	Domain   types.String   `tfsdk:"domain"`
+	IP       types.String   `tfsdk:"ip"`
+	Force    types.Bool     `tfsdk:"force"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &dnsRecordResource{}
+	_ resource.ResourceWithImportState = &dnsRecordResource{}
+)
+
+// newDNSRecordResource returns the local DNS Terraform resource
+func newDNSRecordResource() resource.Resource {
+	return &dnsRecordResource{}
+}
+
+// Metadata returns the resource type name
+func (r *dnsRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record"
+}
+
+// Schema returns the resource schema
+func (r *dnsRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Pi-hole DNS record. " +
+			"Pi-hole replaces its DNS records as a whole list, so when another tool edits them at the same time the last writer wins. The provider writes its own records again if they were lost, but records the other tool added may be lost.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:   "The ID of this resource.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"domain": schema.StringAttribute{
+				Description:   "DNS record domain",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    []validator.String{domainValidator()},
 			},
-			"ip": {
-				Description:      "IP address to route traffic to from the DNS record domain",
-				Type:             schema.TypeString,
-				Required:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validateIPAddress(),
+			"ip": schema.StringAttribute{
+				Description:   "IP address to route traffic to from the DNS record domain",
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    []validator.String{ipAddressValidator()},
 			},
-			"force": {
-				Description: "If true and the record already exists, delete it before creating the new record. Enables upsert/overwrite behavior.",
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				ForceNew:    true,
+			"force": schema.BoolAttribute{
+				Description:   "If true and the record already exists, delete it before creating the new record. Enables upsert/overwrite behavior.",
+				Optional:      true,
+				Computed:      true,
+				Default:       booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true, Read: true, Delete: true}),
+		},
 	}
 }
 
-// resourceDNSRecordCreate handles the creation a local DNS record via Terraform
-func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
-	}
-
-	domain := d.Get("domain").(string)
-	ip := d.Get("ip").(string)
-	force := d.Get("force").(bool)
-
-	// Acquire global mutex to serialize all Pi-hole API operations
-	pm.Lock()
-	defer pm.Unlock()
+// Configure stores the provider meta
+func (r *dnsRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	pm, diags := providerMetaFromData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.pm = pm
+}
 
-	opts := &pihole.CreateOptions{Force: force}
-	_, err := pm.Client.LocalDNS().Create(ctx, domain, ip, opts)
-	if err != nil {
-		return diag.FromErr(err)
+// Create handles the creation a local DNS record via Terraform
+func (r *dnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dnsRecordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	d.SetId(domain)
+	timeout, diags := plan.Timeouts.Create(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	domain := plan.Domain.ValueString()
+
+	// Record writes are batched by the client, so only writes to the same
+	// record need to be ordered
+	r.pm.RLock()
+	defer r.pm.RUnlock()
+	r.pm.LockRecord("dns/" + domain)
+	defer r.pm.UnlockRecord("dns/" + domain)
+
+	opts := &pihole.CreateOptions{Force: plan.Force.ValueBool()}
+	if _, err := r.pm.Client.LocalDNS().Create(ctx, domain, plan.IP.ValueString(), opts); err != nil {
+		addErr(&resp.Diagnostics, err)
+		return
+	}
 
-	return diags
+	plan.ID = types.StringValue(domain)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-// resourceDNSRecordRead finds a local DNS record based on the associated domain ID
-func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
+// Read finds a local DNS record based on the associated domain ID
+func (r *dnsRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dnsRecordModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// Read operations also acquire the mutex to prevent reads during writes
-	pm.Lock()
-	defer pm.Unlock()
+	r.pm.RLock()
+	defer r.pm.RUnlock()
 
-	record, err := pm.Client.LocalDNS().Get(ctx, d.Id())
+	record, err := r.pm.Client.LocalDNS().Get(ctx, state.ID.ValueString())
 	if err != nil {
 		if errors.Is(err, pihole.ErrDNSNotFound) {
-			d.SetId("")
-			return nil
+			resp.State.RemoveResource(ctx)
+			return
 		}
 
-		return diag.FromErr(err)
+		addErr(&resp.Diagnostics, err)
+		return
 	}
 
-	if err = d.Set("domain", record.Domain); err != nil {
-		return diag.FromErr(err)
-	}
+	state.Domain = types.StringValue(record.Domain)
+	state.IP = types.StringValue(record.IP)
 
-	if err = d.Set("ip", record.IP); err != nil {
-		return diag.FromErr(err)
+	// Imported records have no force value yet
+	if state.Force.IsNull() {
+		state.Force = types.BoolValue(false)
 	}
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-// resourceDNSRecordDelete handles the deletion of a local DNS record via Terraform
-func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	pm, diags := getProviderMeta(meta)
-	if diags != nil {
-		return diags
+// Update only stores changed timeouts, every other change replaces the record
+func (r *dnsRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dnsRecordModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Acquire global mutex to serialize all Pi-hole API operations
-	pm.Lock()
-	defer pm.Unlock()
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete handles the deletion of a local DNS record via Terraform
+func (r *dnsRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dnsRecordModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if err := pm.Client.LocalDNS().Delete(ctx, d.Id()); err != nil {
-		return diag.FromErr(err)
+	timeout, diags := state.Timeouts.Delete(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	domain := state.ID.ValueString()
 
-	d.SetId("")
+	// Record writes are batched by the client, so only writes to the same
+	// record need to be ordered
+	r.pm.RLock()
+	defer r.pm.RUnlock()
+	r.pm.LockRecord("dns/" + domain)
+	defer r.pm.UnlockRecord("dns/" + domain)
+
+	if err := r.pm.Client.LocalDNS().Delete(ctx, domain); err != nil {
+		addErr(&resp.Diagnostics, err)
+	}
+}
 
-	return diags
+// ImportState imports a record by its domain
+func (r *dnsRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -12,10 +12,10 @@ import (
 )
 
 func TestAccLocalDNS(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckLocalDNSDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckLocalDNSDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testLocalDNSResourceConfig("foo", "foo.com", "127.0.0.1"),
@@ -80,3 +80,30 @@ func testAccCheckLocalDNSDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestAccLocalDNSTimeouts(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckLocalDNSDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_dns_record" "timeouts" {
+						domain = "timeouts.com"
+						ip     = "127.0.0.1"
+
+						timeouts {
+							create = "1m"
+							delete = "1m"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_record.timeouts", "domain", "timeouts.com"),
+					testCheckLocalDNSResourceExists(t, "timeouts.com", "127.0.0.1"),
+				),
+			},
+		},
+	})
+}
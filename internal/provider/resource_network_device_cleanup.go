@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceNetworkDeviceCleanup returns the Terraform resource that removes stale devices from Pi-hole's network table
+func resourceNetworkDeviceCleanup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Removes devices that have not been seen for a while from Pi-hole's network table. " +
+			"The cleanup runs when the resource is created, and again whenever one of its arguments changes. " +
+			"Destroying the resource only removes it from the Terraform state.",
+		CreateContext: resourceNetworkDeviceCleanupCreate,
+		ReadContext:   resourceNetworkDeviceCleanupRead,
+		DeleteContext: resourceNetworkDeviceCleanupDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"not_seen_within": {
+				Description:      "Remove devices not seen within this duration, such as `720h`. Must be greater than zero.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validatePositiveDuration(),
+			},
+			"interface": {
+				Description: "Only remove devices seen on this interface",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"triggers": {
+				Description: "Arbitrary values that run the cleanup again when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"removed": {
+				Description: "Hardware addresses of the devices that were removed",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourceNetworkDeviceCleanupCreate removes the matching devices from the network table
+func resourceNetworkDeviceCleanupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	pm, diags := getProviderMeta(meta)
+	if diags != nil {
+		return diags
+	}
+
+	// The duration is checked by validatePositiveDuration, so that the filter
+	// never matches every device
+	notSeenWithin, _ := time.ParseDuration(d.Get("not_seen_within").(string))
+	filter := networkDeviceFilter{
+		iface:         d.Get("interface").(string),
+		notSeenWithin: notSeenWithin,
+	}
+
+	pm.Lock()
+	defer pm.Unlock()
+
+	devices, err := pm.Client.Network().List(ctx)
+	if err != nil {
+		return diagFromErr(err)
+	}
+
+	now := time.Now()
+	removed := make([]string, 0)
+	for _, device := range devices {
+		if !filter.match(device, now) {
+			continue
+		}
+
+		if err := pm.Client.Network().Delete(ctx, device.ID); err != nil {
+			return diagFromErr(fmt.Errorf("failed to remove device %s: %w", device.HWAddr, err))
+		}
+		removed = append(removed, device.HWAddr)
+	}
+
+	if err := d.Set("removed", removed); err != nil {
+		return diagFromErr(err)
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d", filter.iface, filter.notSeenWithin, now.UnixNano())))
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+
+	return diags
+}
+
+// resourceNetworkDeviceCleanupRead is a no-op, the cleanup has no remote state to refresh
+func resourceNetworkDeviceCleanupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceNetworkDeviceCleanupDelete removes the cleanup from the state without touching Pi-hole
+func resourceNetworkDeviceCleanupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
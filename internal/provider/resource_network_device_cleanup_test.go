@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole/fake"
+)
+
+// testAccNetworkDeviceCleanupConfig is restricted to an interface that only
+// exists in the fake, so running against a real Pi-hole removes nothing
+const testAccNetworkDeviceCleanupConfig = `
+	resource "pihole_network_device_cleanup" "stale" {
+	  not_seen_within = "720h"
+	  interface       = "tf-acc-test0"
+	}
+`
+
+func TestAccNetworkDeviceCleanup(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkDeviceCleanupConfig,
+				Check:  resource.TestCheckResourceAttrSet("pihole_network_device_cleanup.stale", "id"),
+			},
+		},
+	})
+}
+
+// TestAccNetworkDeviceCleanupZero tests that a zero duration, which would
+// remove every device, is rejected
+func TestAccNetworkDeviceCleanupZero(t *testing.T) {
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_network_device_cleanup" "all" {
+					  not_seen_within = "0s"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`must be greater than zero`),
+			},
+		},
+	})
+}
+
+func TestAccNetworkDeviceCleanupRemoves(t *testing.T) {
+	srv := testAccFake(t, func(srv *fake.Server) {
+		srv.AddDevice(fake.Device{HWAddr: "aa:bb:cc:dd:ee:01", Interface: "tf-acc-test0", LastQuery: time.Now().Unix()})
+		srv.AddDevice(fake.Device{HWAddr: "aa:bb:cc:dd:ee:02", Interface: "tf-acc-test0"})
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkDeviceCleanupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_network_device_cleanup.stale", "removed.0", "aa:bb:cc:dd:ee:02"),
+					func(s *terraform.State) error {
+						if n := len(srv.Devices()); n != 1 {
+							return fmt.Errorf("expected only the active device to remain, got %d devices", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
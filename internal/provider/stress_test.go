@@ -2,16 +2,18 @@ package provider
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 // Stress test record counts.
-// Keep counts low to avoid overwhelming Pi-hole's dnsmasq during ForceNew operations.
-// Pi-hole's delete is eventually consistent - dnsmasq may not process deletes immediately,
-// causing "Item already present" errors during rapid delete+create cycles.
-const (
+// They stay low by default to keep the suite fast. Setting PIHOLE_STRESS_LARGE
+// raises them, which is safe as writes only complete once they are visible
+// when read back, so delete+create cycles during ForceNew no longer race
+// dnsmasq.
+var (
 	// stressBulkCount is used for bulk create/delete tests
 	stressBulkCount = 10
 	// stressBulkReducedCount is the reduced count for bulk delete step
@@ -26,14 +28,25 @@ const (
 	stressRapidCount = 3
 )
 
+func init() {
+	if os.Getenv("PIHOLE_STRESS_LARGE") != "" {
+		stressBulkCount = 50
+		stressBulkReducedCount = 10
+		stressMixedCount = 25
+		stressMixedReducedCount = 10
+		stressMixedFinalCount = 35
+		stressRapidCount = 10
+	}
+}
+
 // TestAccStressBulkCreate tests creating many DNS and CNAME records simultaneously.
-// This exercises the global mutex to ensure no race conditions during bulk operations.
+// This exercises the operation lock to ensure no race conditions during bulk operations.
 func TestAccStressBulkCreate(t *testing.T) {
 	lastIdx := stressBulkCount - 1
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckLocalDNSDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckLocalDNSDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testStressBulkCreateConfig(stressBulkCount),
@@ -57,10 +70,10 @@ func TestAccStressBulkCreate(t *testing.T) {
 // TestAccStressBulkDelete tests deleting many records at once by reducing the count.
 func TestAccStressBulkDelete(t *testing.T) {
 	reducedLastIdx := stressBulkReducedCount - 1
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckLocalDNSDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckLocalDNSDestroy,
 		Steps: []resource.TestStep{
 			// First create stressBulkCount records
 			{
@@ -83,10 +96,10 @@ func TestAccStressBulkDelete(t *testing.T) {
 // TestAccStressForceNew tests ForceNew behavior by changing IP/target values
 // This triggers delete+create sequences that must be atomic
 func TestAccStressForceNew(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckLocalDNSDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckLocalDNSDestroy,
 		Steps: []resource.TestStep{
 			// Create initial records
 			{
@@ -121,10 +134,10 @@ func TestAccStressMixedOperations(t *testing.T) {
 	mixedLastIdx := stressMixedCount - 1
 	reducedLastIdx := stressMixedReducedCount - 1
 	finalLastIdx := stressMixedFinalCount - 1
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckLocalDNSDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckLocalDNSDestroy,
 		Steps: []resource.TestStep{
 			// Step 1: Create stressMixedCount DNS and CNAME records
 			{
@@ -171,10 +184,10 @@ func TestAccStressMixedOperations(t *testing.T) {
 // TestAccStressRapidReplace tests rapid sequential replacements
 // This exercises the mutex heavily by doing many ForceNew cycles back to back
 func TestAccStressRapidReplace(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckLocalDNSDestroy,
+	testAccRun(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckLocalDNSDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testStressRapidConfig("10.0.1"),
@@ -1,27 +1,17 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"net"
-	"regexp"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poindexter12/terraform-provider-pihole/internal/pihole"
 )
 
-// domainRegex matches valid domain names.
-// Allows alphanumeric characters, hyphens, underscores, and dots.
-// Each label must start and end with alphanumeric characters.
-var domainRegex = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9_-]*[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9_-]*[a-zA-Z0-9])?$`)
-
-// validateDomain returns a schema validation function for domain names.
-func validateDomain() schema.SchemaValidateDiagFunc {
-	return validation.ToDiagFunc(validation.All(
-		validation.StringLenBetween(1, 253),
-		validation.StringMatch(domainRegex, "must be a valid domain name"),
-	))
-}
-
 // validateIPAddress returns a schema validation function for IP addresses.
 // Accepts both IPv4 and IPv6 addresses.
 func validateIPAddress() schema.SchemaValidateDiagFunc {
@@ -33,3 +23,101 @@ func validateIPAddress() schema.SchemaValidateDiagFunc {
 		return warnings, errors
 	})
 }
+
+// validateDuration returns a schema validation function for durations
+// such as "30s" or "1m30s".
+func validateDuration() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(v interface{}, k string) (warnings []string, errors []error) {
+		value := v.(string)
+		if d, err := time.ParseDuration(value); err != nil {
+			errors = append(errors, fmt.Errorf("%q is not a valid duration: %s", k, value))
+		} else if d < 0 {
+			errors = append(errors, fmt.Errorf("%q must not be negative: %s", k, value))
+		}
+		return warnings, errors
+	})
+}
+
+// validatePositiveDuration returns a schema validation function for durations
+// that must be greater than zero, for arguments where zero would disable a
+// safeguard rather than set a limit
+func validatePositiveDuration() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(v interface{}, k string) (warnings []string, errors []error) {
+		value := v.(string)
+		if d, err := time.ParseDuration(value); err != nil {
+			errors = append(errors, fmt.Errorf("%q is not a valid duration: %s", k, value))
+		} else if d <= 0 {
+			errors = append(errors, fmt.Errorf("%q must be greater than zero: %s", k, value))
+		}
+		return warnings, errors
+	})
+}
+
+// stringValidator is a framework validator running check on known string
+// values. It lets framework resources validate like their SDKv2 counterparts.
+type stringValidator struct {
+	description string
+	check       func(value string) error
+}
+
+// Description describes the validation
+func (v stringValidator) Description(ctx context.Context) string {
+	return v.description
+}
+
+// MarkdownDescription describes the validation
+func (v stringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.description
+}
+
+// ValidateString reports the error returned by check for the configured value
+func (v stringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := v.check(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", err.Error())
+	}
+}
+
+// domainValidator returns a framework validator for domain names
+func domainValidator() validator.String {
+	return stringValidator{
+		description: "value must be a valid domain name",
+		check: func(value string) error {
+			if len(value) < 1 || len(value) > pihole.MaxDomainLength {
+				return fmt.Errorf("expected length to be in the range (1 - %d), got %d", pihole.MaxDomainLength, len(value))
+			}
+			if !pihole.DomainRegex.MatchString(value) {
+				return fmt.Errorf("%q must be a valid domain name", value)
+			}
+			return nil
+		},
+	}
+}
+
+// clientIDValidator returns a framework validator for Pi-hole client
+// identifiers
+func clientIDValidator() validator.String {
+	return stringValidator{
+		description: "value must be an IP address, CIDR range, MAC address, hostname or interface",
+		check: func(value string) error {
+			_, _, err := pihole.ParseClientID(value)
+			return err
+		},
+	}
+}
+
+// ipAddressValidator returns a framework validator for IPv4 and IPv6 addresses
+func ipAddressValidator() validator.String {
+	return stringValidator{
+		description: "value must be a valid IP address",
+		check: func(value string) error {
+			if net.ParseIP(value) == nil {
+				return fmt.Errorf("%q is not a valid IP address", value)
+			}
+			return nil
+		},
+	}
+}
@@ -1,12 +1,30 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/poindexter12/terraform-provider-pihole/internal/generate"
 	"github.com/poindexter12/terraform-provider-pihole/internal/provider"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: provider.Provider,
-	})
+	ctx := context.Background()
+
+	// Terraform starts the provider without arguments, so a subcommand means
+	// the binary was run by hand
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate.Run(ctx, os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	server, err := provider.ProviderServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tf5server.Serve("registry.terraform.io/poindexter12/pihole", server); err != nil {
+		log.Fatal(err)
+	}
 }